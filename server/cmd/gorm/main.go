@@ -27,7 +27,7 @@ func main() {
 
 	var dataMap = map[string]func(gorm.ColumnType) string{
 		"DECIMAL": func(dataType gorm.ColumnType) string {
-			return "float32"
+			return "money.Money"
 		},
 		"INT": func(dataType gorm.ColumnType) string {
 			return "int"
@@ -37,6 +37,7 @@ func main() {
 		},
 	}
 	g.WithDataTypeMap(dataMap)
+	g.WithImportPkgPath("server/internal/lib/money")
 
 	g.GenerateAllTable()
 
@@ -49,6 +50,13 @@ func main() {
 		gen.FieldRelate(field.HasMany, "DishOrders", g.GenerateModel("dish_orders"), &field.RelateConfig{}),
 	)
 
+	g.GenerateModel("user_calendar_tokens",
+		gen.FieldRelate(field.BelongsTo, "User", users, &field.RelateConfig{}),
+	)
+
+	g.GenerateModel("balance_transactions")
+	g.GenerateModel("payments")
+
 	processorProducers := g.GenerateModel("processor_producers",
 		gen.FieldRelate(field.HasMany, "Processors", g.GenerateModel("processors"), &field.RelateConfig{}),
 	)
@@ -88,12 +96,24 @@ func main() {
 		gen.FieldRelate(field.BelongsTo, "RAM", ram, &field.RelateConfig{}),
 		gen.FieldRelate(field.HasMany, "Pcs", g.GenerateModel("pc"), &field.RelateConfig{}),
 		gen.FieldRelate(field.HasMany, "PcTypeImages", g.GenerateModel("pc_type_images"), &field.RelateConfig{}),
+		gen.FieldRelate(field.HasMany, "PcTypeTariffs", g.GenerateModel("pc_type_tariffs"), &field.RelateConfig{}),
 	)
 
 	g.GenerateModel("pc_type_images",
 		gen.FieldRelate(field.BelongsTo, "PcType", pcTypes, &field.RelateConfig{}),
 	)
 
+	g.GenerateModel("pc_type_tariffs",
+		gen.FieldRelate(field.BelongsTo, "PcType", pcTypes, &field.RelateConfig{}),
+	)
+
+	hourPackages := g.GenerateModel("hour_packages",
+		gen.FieldRelate(field.BelongsTo, "PcType", pcTypes, &field.RelateConfig{}),
+	)
+	g.GenerateModel("user_hour_packages",
+		gen.FieldRelate(field.BelongsTo, "HourPackage", hourPackages, &field.RelateConfig{}),
+	)
+
 	pcRooms := g.GenerateModel("pc_rooms",
 		gen.FieldRelate(field.HasMany, "Pcs", g.GenerateModel("pc"), &field.RelateConfig{}),
 	)
@@ -110,12 +130,18 @@ func main() {
 	pcOrderStatuses := g.GenerateModel("pc_order_statuses",
 		gen.FieldRelate(field.HasMany, "PcOrders", g.GenerateModel("pc_orders"), &field.RelateConfig{}),
 	)
-	g.GenerateModel("pc_orders",
+	pcOrders := g.GenerateModel("pc_orders",
 		gen.FieldRelate(field.BelongsTo, "User", users, &field.RelateConfig{}),
 		gen.FieldRelate(field.BelongsTo, "Pc", pc, &field.RelateConfig{}),
 		gen.FieldRelate(field.BelongsTo, "PcOrderStatus", pcOrderStatuses, &field.RelateConfig{}),
 	)
 
+	g.GenerateModel("pc_order_status_history",
+		gen.FieldRelate(field.BelongsTo, "PcOrder", pcOrders, &field.RelateConfig{}),
+	)
+
+	g.GenerateModel("pc_order_hour_packages")
+
 	dishStatuses := g.GenerateModel("dish_statuses",
 		gen.FieldRelate(field.HasMany, "Dishes", g.GenerateModel("dishes"), &field.RelateConfig{}),
 	)
@@ -125,6 +151,10 @@ func main() {
 		gen.FieldRelate(field.HasMany, "DishOrderList", g.GenerateModel("dish_order_list"), &field.RelateConfig{}),
 	)
 
+	g.GenerateModel("dish_stocks",
+		gen.FieldRelate(field.BelongsTo, "Dish", dishes, &field.RelateConfig{}),
+	)
+
 	g.GenerateModel("dish_images",
 		gen.FieldRelate(field.BelongsTo, "Dish", dishes, &field.RelateConfig{}),
 	)
@@ -135,6 +165,7 @@ func main() {
 	dishOrders := g.GenerateModel("dish_orders",
 		gen.FieldRelate(field.BelongsTo, "DishOrderStatus", dishOrderStatuses, &field.RelateConfig{}),
 		gen.FieldRelate(field.BelongsTo, "User", users, &field.RelateConfig{}),
+		gen.FieldRelate(field.BelongsTo, "PcOrder", pcOrders, &field.RelateConfig{}),
 		gen.FieldRelate(field.HasMany, "DishOrderList", g.GenerateModel("dish_order_list"), &field.RelateConfig{}),
 	)
 
@@ -143,5 +174,18 @@ func main() {
 		gen.FieldRelate(field.BelongsTo, "Dish", dishes, &field.RelateConfig{}),
 	)
 
+	g.GenerateModel("dish_order_status_history",
+		gen.FieldRelate(field.BelongsTo, "DishOrder", dishOrders, &field.RelateConfig{}),
+	)
+
+	promotions := g.GenerateModel("promotions",
+		gen.FieldRelate(field.HasMany, "PromotionPcTypes", g.GenerateModel("promotion_pc_types"), &field.RelateConfig{}),
+		gen.FieldRelate(field.HasMany, "PromotionPcRooms", g.GenerateModel("promotion_pc_rooms"), &field.RelateConfig{}),
+		gen.FieldRelate(field.HasMany, "PromotionDishes", g.GenerateModel("promotion_dishes"), &field.RelateConfig{}),
+	)
+	g.GenerateModel("promotion_redemptions",
+		gen.FieldRelate(field.BelongsTo, "Promotion", promotions, &field.RelateConfig{}),
+	)
+
 	g.Execute()
 }
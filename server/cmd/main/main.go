@@ -33,6 +33,10 @@ func main() {
 
 	log.Info("server running")
 
+	go application.Scheduler.Run()
+
+	log.Info("scheduler running")
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 
@@ -47,6 +51,14 @@ func main() {
 	}
 
 	log.Info("server stopped")
+
+	if err := application.Scheduler.Stop(ctx); err != nil {
+		log.Error("failed stop scheduler")
+
+		panic(err)
+	}
+
+	log.Info("scheduler stopped")
 }
 
 func setupLogger(env string) *slog.Logger {
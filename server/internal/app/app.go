@@ -4,25 +4,42 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	pcClubApp "server/internal/app/pcCLub"
+	"net/http"
+	pcClubApp "server/internal/app/pcClub"
+	"server/internal/app/scheduler"
 	"server/internal/config"
 	pcClubServer "server/internal/http-server/handlers/pcCLub"
+	"server/internal/lib/payments"
+	"server/internal/lib/payments/mock"
 	"server/internal/services/pcClub/auth"
+	"server/internal/services/pcClub/balance"
+	"server/internal/services/pcClub/calendar"
 	"server/internal/services/pcClub/components/monitor"
 	"server/internal/services/pcClub/components/processor"
 	"server/internal/services/pcClub/components/ram"
 	"server/internal/services/pcClub/components/videoCard"
 	"server/internal/services/pcClub/dish"
+	"server/internal/services/pcClub/dishImage"
+	"server/internal/services/pcClub/dishStock"
+	"server/internal/services/pcClub/history"
+	"server/internal/services/pcClub/hourPackage"
+	"server/internal/services/pcClub/orderDish"
+	"server/internal/services/pcClub/orderPc"
+	"server/internal/services/pcClub/payment"
 	"server/internal/services/pcClub/pc"
 	"server/internal/services/pcClub/pcRoom"
 	"server/internal/services/pcClub/pcType"
+	"server/internal/services/pcClub/pcTypeImage"
+	"server/internal/services/pcClub/promotion"
+	"server/internal/services/pcClub/tariff"
 	"server/internal/services/pcClub/user"
 	gorm "server/internal/storage/mssql"
 	"server/internal/storage/redis"
 )
 
 type App struct {
-	PCClub *pcClubApp.App
+	PCClub    *pcClubApp.App
+	Scheduler *scheduler.App
 }
 
 func MustLoad(
@@ -65,6 +82,21 @@ func New(
 	videoCardService := videoCard.New(mssqlStorage, mssqlStorage, redisStorage, redisStorage)
 	ramService := ram.New(mssqlStorage, mssqlStorage, redisStorage, redisStorage)
 	dishService := dish.New(mssqlStorage, mssqlStorage, redisStorage, redisStorage)
+	tariffService := tariff.New(mssqlStorage, mssqlStorage)
+	hourPackageService := hourPackage.New(mssqlStorage, mssqlStorage)
+	promotionService := promotion.New(mssqlStorage, mssqlStorage)
+	historyService := history.New(mssqlStorage)
+	calendarService := calendar.New(mssqlStorage, mssqlStorage)
+	orderDishService := orderDish.New(log, mssqlStorage, mssqlStorage, mssqlStorage, dishService)
+	dishStockService := dishStock.New(log, mssqlStorage, mssqlStorage, dishService)
+	dishImageService := dishImage.New(cfg.Images.Dishes, mssqlStorage, mssqlStorage, dishService)
+	pcTypeImageService := pcTypeImage.New(cfg.Images.PCS, mssqlStorage, mssqlStorage, pcTypeService)
+	balanceService := balance.New(mssqlStorage, mssqlStorage)
+
+	gateway, paymentCheckout := newPaymentGateway(log, cfg.Payments)
+	paymentService := payment.New(cfg.Payments, gateway, mssqlStorage, mssqlStorage)
+
+	orderPcService := orderPc.New(cfg.PcOrder, mssqlStorage, mssqlStorage, mssqlStorage, mssqlStorage, mssqlStorage)
 
 	pcClubApi := pcClubServer.New(
 		log,
@@ -81,11 +113,64 @@ func New(
 			Ram:       ramService,
 		},
 		dishService,
+		orderPcService,
+		tariffService,
+		hourPackageService,
+		promotionService,
+		historyService,
+		calendarService,
+		orderDishService,
+		dishStockService,
+		dishImageService,
+		pcTypeImageService,
+		balanceService,
+		paymentService,
+		paymentCheckout,
 	)
 
 	pcClubApplication := pcClubApp.New(cfg.HttpsServer, pcClubApi)
 
+	schedulerApplication, err := scheduler.New(
+		log,
+		&cfg.Scheduler,
+		scheduler.Job{
+			Name: "expire no show pc orders",
+			Run:  orderPcService.ExpireNoShowPcOrders,
+		},
+		scheduler.Job{
+			Name: "finish ended pc orders",
+			Run:  orderPcService.FinishEndedPcOrders,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to create scheduler: %w", op, err)
+	}
+
 	return &App{
-		PCClub: pcClubApplication,
+		PCClub:    pcClubApplication,
+		Scheduler: schedulerApplication,
 	}, nil
 }
+
+// newPaymentGateway returns configured payment provider and handler of its
+// local checkout pages, the handler is nil for providers with hosted checkout.
+// Without configured provider both are nil and top-ups are disabled
+func newPaymentGateway(log *slog.Logger, cfg *config.PaymentsConfig) (payments.Provider, http.Handler) {
+	if cfg == nil || cfg.Provider == "" {
+		log.Warn("payment provider is not configured, top-ups are disabled")
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case mock.Name:
+		if cfg.Mock == nil {
+			log.Warn("mock payment provider is not configured, top-ups are disabled")
+			return nil, nil
+		}
+		provider := mock.New(cfg.Mock, cfg.WebhookPath)
+		return provider, provider.Handler()
+	default:
+		log.Warn("unknown payment provider, top-ups are disabled", slog.String("provider", cfg.Provider))
+		return nil, nil
+	}
+}
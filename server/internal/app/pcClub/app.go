@@ -11,6 +11,7 @@ import (
 	"server/internal/config"
 	"server/internal/http-server/handlers/pcCLub"
 	"server/internal/http-server/middleware/auth/authAdmin"
+	"server/internal/http-server/middleware/auth/authKitchen"
 	"server/internal/http-server/middleware/auth/authorization"
 	"server/internal/http-server/middleware/logger"
 	"server/internal/lib/api/logger/sl"
@@ -48,7 +49,12 @@ func New(cfg *config.HTTPSServerConfig, api *pcCLub.API) *App {
 
 	r.Get("/pc-types", api.PcTypes())
 	r.Get("/pcs", api.Pcs())
+	r.Get("/pcs-timeline", api.PcsTimeline())
 	r.Get("/pc-type/{type-id}", api.PcType())
+	r.Get("/pc-type-tariffs/{type-id}", api.PcTypeTariffs())
+	r.Get("/pc-type-images/{type-id}", api.PcTypeImages())
+	r.Handle(api.Cfg.Images.PCS.UrlPath+"/*", api.PcTypeImageFiles())
+	r.Get("/hour-packages", api.HourPackages())
 
 	r.Get("/pc-room/{room-id}", api.PcRoom())
 
@@ -66,12 +72,57 @@ func New(cfg *config.HTTPSServerConfig, api *pcCLub.API) *App {
 
 	r.Get("/dishes", api.Dishes())
 	r.Get("/dish/{dish-id}", api.Dish())
+	r.Get("/dish-images/{dish-id}", api.DishImages())
+	r.Handle(api.Cfg.Images.Dishes.UrlPath+"/*", api.DishImageFiles())
+
+	r.Post("/check-in-pc-order", api.CheckInOrderPc())
+
+	r.Get("/calendar/{token}", api.CalendarFeed())
+
+	if api.PaymentService.Enabled() {
+		r.Post(api.Cfg.Payments.WebhookPath, api.PaymentWebhook())
+		if api.PaymentCheckout != nil {
+			r.Mount(api.Cfg.Payments.Mock.UrlPath, api.PaymentCheckout)
+		}
+	}
 
 	//routes to be authorized
 	r.Group(func(r chi.Router) {
 		r.Use(authorization.Authorize(api.Log, api.AuthService))
 
 		r.Post("/user", api.User())
+
+		r.Get("/pc-orders", api.OrderPcs())
+		r.Get("/order-history", api.OrderHistory())
+		r.Post("/calendar-token", api.CalendarToken())
+		r.Get("/pc-order-quote", api.QuoteOrderPc())
+		r.Post("/save-pc-order", api.SaveOrderPc())
+		r.Post("/cancel-pc-order", api.CancelOrderPc())
+		r.Post("/extend-pc-order", api.ExtendOrderPc())
+		r.Post("/end-pc-order", api.EndOrderPc())
+
+		r.Get("/dish-orders", api.OrderDishes())
+		r.Post("/save-dish-order", api.SaveOrderDish())
+
+		r.Get("/user-hour-packages", api.UserHourPackages())
+		r.Post("/buy-hour-package", api.BuyHourPackage())
+
+		r.Get("/balance-statement", api.BalanceStatement())
+		if api.PaymentService.Enabled() {
+			r.Post("/top-up", api.TopUp())
+		}
+		r.Get("/payments", api.Payments())
+		r.Get("/payment/{payment-id}", api.Payment())
+	})
+
+	//kitchen routes
+	r.Group(func(r chi.Router) {
+		r.Use(authKitchen.AuthKitchen(api.Log, api.AuthService, api.UserService))
+
+		r.Get("/kitchen-dish-orders", api.KitchenOrderDishes())
+		r.Post("/kitchen-dish-order-status", api.KitchenChangeOrderDishStatus())
+		r.Get("/dish-stocks", api.DishStocks())
+		r.Post("/restock-dish", api.RestockDish())
 	})
 
 	//admin routes
@@ -85,10 +136,38 @@ func New(cfg *config.HTTPSServerConfig, api *pcCLub.API) *App {
 		r.Post("/delete-pc-type", api.DeletePcType())
 		r.Post("/delete-pc", api.DeletePc())
 
+		r.Post("/upload-pc-type-image", api.UploadPcTypeImage())
+		r.Post("/reorder-pc-type-images", api.ReorderPcTypeImages())
+		r.Post("/set-main-pc-type-image", api.SetMainPcTypeImage())
+		r.Post("/delete-pc-type-image", api.DeletePcTypeImage())
+
+		r.Post("/save-pc-type-tariff", api.SavePcTypeTariff())
+		r.Post("/update-pc-type-tariff", api.UpdatePcTypeTariff())
+		r.Post("/delete-pc-type-tariff", api.DeletePcTypeTariff())
+
+		r.Post("/save-hour-package", api.SaveHourPackage())
+		r.Post("/update-hour-package", api.UpdateHourPackage())
+		r.Post("/delete-hour-package", api.DeleteHourPackage())
+
+		r.Get("/promotions", api.Promotions())
+		r.Post("/save-promotion", api.SavePromotion())
+		r.Post("/update-promotion", api.UpdatePromotion())
+		r.Post("/delete-promotion", api.DeletePromotion())
+
 		r.Post("/save-pc-room", api.SavePcRoom())
 		r.Post("/update-pc-room", api.UpdatePcRoom())
 		r.Post("/delete-pc-room", api.DeletePcRoom())
 
+		r.Get("/admin-pc-orders", api.AdminOrderPcs())
+		r.Post("/admin-cancel-pc-order", api.AdminCancelOrderPc())
+		r.Post("/admin-move-pc-order", api.AdminMoveOrderPc())
+
+		r.Get("/admin-balance-statement", api.AdminBalanceStatement())
+		r.Post("/admin-top-up-balance", api.AdminTopUpBalance())
+		r.Post("/admin-adjust-balance", api.AdminAdjustBalance())
+		r.Post("/admin-reconcile-balance", api.AdminReconcileBalance())
+		r.Post("/admin-refund-payment", api.AdminRefundPayment())
+
 		r.Post("/save-monitor-producer", api.SaveMonitorProducer())
 		r.Post("/save-monitor", api.SaveMonitor())
 		r.Post("/delete-monitor-producer", api.DeleteMonitorProducer())
@@ -112,6 +191,13 @@ func New(cfg *config.HTTPSServerConfig, api *pcCLub.API) *App {
 		r.Post("/save-dish", api.SaveDish())
 		r.Post("/update-dish", api.UpdateDish())
 		r.Post("/delete-dish", api.DeleteDish())
+
+		r.Post("/save-dish-stock", api.SaveDishStock())
+		r.Post("/delete-dish-stock", api.DeleteDishStock())
+
+		r.Post("/upload-dish-image", api.UploadDishImage())
+		r.Post("/set-main-dish-image", api.SetMainDishImage())
+		r.Post("/delete-dish-image", api.DeleteDishImage())
 	})
 
 	srv := &http.Server{
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"server/internal/config"
+	"server/internal/lib/api/logger/sl"
+	"sync"
+	"time"
+)
+
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) (affected int64, err error)
+}
+
+type App struct {
+	Log    *slog.Logger
+	Cfg    *config.SchedulerConfig
+	jobs   []Job
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu      sync.Mutex
+	started bool
+	stopped bool
+}
+
+// New returns scheduler running jobs every configured interval,
+// it fails when the interval is not positive
+func New(log *slog.Logger, cfg *config.SchedulerConfig, jobs ...Job) (*App, error) {
+	const op = "app.scheduler.New"
+
+	if cfg == nil {
+		return nil, fmt.Errorf("%s: scheduler config is missing", op)
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("%s: scheduler interval must be positive, got %s", op, cfg.Interval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &App{
+		Log:    log,
+		Cfg:    cfg,
+		jobs:   jobs,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Run runs all jobs every interval until Stop is called
+func (a *App) Run() {
+	const op = "app.scheduler.Run"
+
+	log := a.Log.With(
+		slog.String("operation", op),
+	)
+
+	a.mu.Lock()
+	if a.started || a.stopped {
+		a.mu.Unlock()
+		return
+	}
+	a.started = true
+	a.mu.Unlock()
+
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.Cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		a.runJobs(log)
+
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *App) runJobs(log *slog.Logger) {
+	for _, job := range a.jobs {
+		if a.ctx.Err() != nil {
+			return
+		}
+
+		affected, err := job.Run(a.ctx)
+		if err != nil {
+			log.Error("job failed", slog.String("job", job.Name), sl.Err(err))
+			continue
+		}
+		if affected > 0 {
+			log.Info("job done", slog.String("job", job.Name), slog.Int64("affected", affected))
+		}
+	}
+}
+
+// Stop cancels running jobs and waits for Run to return,
+// it returns at once when Run has not been started
+func (a *App) Stop(ctx context.Context) error {
+	const op = "app.scheduler.Stop"
+
+	a.mu.Lock()
+	a.stopped = true
+	started := a.started
+	a.mu.Unlock()
+
+	a.cancel()
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-a.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s: failed to wait jobs: %w", op, ctx.Err())
+	}
+}
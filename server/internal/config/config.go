@@ -40,11 +40,17 @@ type HTTPSServerConfig struct {
 }
 
 type PCSImagesConfig struct {
-	Path string `yaml:"path"`
+	Path        string        `yaml:"path"`
+	UrlPath     string        `yaml:"url_path"`
+	MaxSize     int64         `yaml:"max_size"`
+	CacheMaxAge time.Duration `yaml:"cache_max_age"`
 }
 
 type DishesImagesConfig struct {
-	Path string `yaml:"path"`
+	Path        string        `yaml:"path"`
+	UrlPath     string        `yaml:"url_path"`
+	MaxSize     int64         `yaml:"max_size"`
+	CacheMaxAge time.Duration `yaml:"cache_max_age"`
 }
 
 type ImagesConfig struct {
@@ -70,7 +76,38 @@ type AuthConfig struct {
 }
 
 type UserConfig struct {
-	AdminRoleName string `yaml:"admin_role_name"`
+	AdminRoleName   string `yaml:"admin_role_name"`
+	KitchenRoleName string `yaml:"kitchen_role_name"`
+}
+
+type RefundTierConfig struct {
+	Before  time.Duration `yaml:"before"`
+	Percent int           `yaml:"percent"`
+}
+
+type PcOrderConfig struct {
+	EarlyCheckIn time.Duration      `yaml:"early_check_in"`
+	GracePeriod  time.Duration      `yaml:"grace_period"`
+	Refunds      []RefundTierConfig `yaml:"refunds"`
+	RefundUnused bool               `yaml:"refund_unused"`
+}
+
+type MockPaymentsConfig struct {
+	Secret   string `yaml:"secret"`
+	UrlPath  string `yaml:"url_path"`
+	BaseURL  string `yaml:"base_url"`
+	Insecure bool   `yaml:"insecure"`
+}
+
+type PaymentsConfig struct {
+	Provider    string              `yaml:"provider"`
+	ReturnURL   string              `yaml:"return_url"`
+	WebhookPath string              `yaml:"webhook_path"`
+	Mock        *MockPaymentsConfig `yaml:"mock"`
+}
+
+type SchedulerConfig struct {
+	Interval time.Duration `yaml:"interval" env-default:"1m"`
 }
 
 type Config struct {
@@ -80,6 +117,9 @@ type Config struct {
 	Images      *ImagesConfig      `yaml:"images"`
 	Auth        *AuthConfig        `yaml:"auth"`
 	User        *UserConfig        `yaml:"user"`
+	PcOrder     *PcOrderConfig     `yaml:"pc_order"`
+	Payments    *PaymentsConfig    `yaml:"payments"`
+	Scheduler   SchedulerConfig    `yaml:"scheduler"`
 }
 
 func MustLoad() *Config {
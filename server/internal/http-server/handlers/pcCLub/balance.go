@@ -0,0 +1,197 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/services/pcClub/balance"
+)
+
+const defaultStatementLimit = 50
+
+type BalanceStatementRequest struct {
+	Limit  int `get:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int `get:"offset" validate:"omitempty,min=0"`
+}
+
+type AdminBalanceStatementRequest struct {
+	UserId int64 `get:"user-id" validate:"required,min=1"`
+	Limit  int   `get:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int   `get:"offset" validate:"omitempty,min=0"`
+}
+
+type AdminTopUpBalanceRequest struct {
+	UserId  int64       `json:"user_id" validate:"required,min=1"`
+	Amount  money.Money `json:"amount" validate:"required,gt=0"`
+	Comment string      `json:"comment" validate:"omitempty,max=255"`
+}
+
+type AdminAdjustBalanceRequest struct {
+	UserId  int64       `json:"user_id" validate:"required,min=1"`
+	Amount  money.Money `json:"amount" validate:"required"`
+	Comment string      `json:"comment" validate:"required,max=255"`
+}
+
+type AdminReconcileBalanceRequest struct {
+	UserId int64 `json:"user_id" validate:"required,min=1"`
+}
+
+func (a *API) BalanceStatement() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.balance.BalanceStatement"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateGETRequest[BalanceStatementRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		a.balanceStatement(w, r, log, uid, req.Limit, req.Offset)
+	}
+}
+
+func (a *API) AdminBalanceStatement() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.balance.AdminBalanceStatement"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[AdminBalanceStatementRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		a.balanceStatement(w, r, log, req.UserId, req.Limit, req.Offset)
+	}
+}
+
+func (a *API) AdminTopUpBalance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.balance.AdminTopUpBalance"
+
+		log := a.log(op, r)
+
+		adminID := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminTopUpBalanceRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		transaction, err := a.BalanceService.TopUpBalance(r.Context(), req.UserId, adminID, req.Amount, req.Comment)
+		if err != nil {
+			var balanceErr *balance.Error
+			if errors.As(err, &balanceErr) {
+				log.Warn("balance error", sl.Err(err))
+				response.BalanceError(w, balanceErr)
+				return
+			}
+			log.Error("failed to top up balance", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, transaction)
+	}
+}
+
+func (a *API) AdminAdjustBalance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.balance.AdminAdjustBalance"
+
+		log := a.log(op, r)
+
+		adminID := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminAdjustBalanceRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		transaction, err := a.BalanceService.AdjustBalance(r.Context(), req.UserId, adminID, req.Amount, req.Comment)
+		if err != nil {
+			var balanceErr *balance.Error
+			if errors.As(err, &balanceErr) {
+				log.Warn("balance error", sl.Err(err))
+				response.BalanceError(w, balanceErr)
+				return
+			}
+			log.Error("failed to adjust balance", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, transaction)
+	}
+}
+
+func (a *API) AdminReconcileBalance() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.balance.AdminReconcileBalance"
+
+		log := a.log(op, r)
+
+		adminID := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminReconcileBalanceRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		transaction, err := a.BalanceService.ReconcileBalance(r.Context(), req.UserId, adminID)
+		if err != nil {
+			var balanceErr *balance.Error
+			if errors.As(err, &balanceErr) {
+				log.Warn("balance error", sl.Err(err))
+				response.BalanceError(w, balanceErr)
+				return
+			}
+			log.Error("failed to reconcile balance", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		if transaction.BalanceTransactionID == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		render.JSON(w, r, transaction)
+	}
+}
+
+func (a *API) balanceStatement(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	uid int64,
+	limit int,
+	offset int,
+) {
+	if limit == 0 {
+		limit = defaultStatementLimit
+	}
+
+	statement, err := a.BalanceService.Statement(r.Context(), uid, limit, offset)
+	if err != nil {
+		var balanceErr *balance.Error
+		if errors.As(err, &balanceErr) {
+			log.Warn("balance error", sl.Err(err))
+			response.BalanceError(w, balanceErr)
+			return
+		}
+		log.Error("failed to get balance statement", sl.Err(err))
+		response.Internal(w)
+		return
+	}
+
+	render.JSON(w, r, statement)
+}
@@ -0,0 +1,90 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/services/pcClub/calendar"
+	"strings"
+)
+
+// calendarFeedExt ends the feed url, calendar apps rely on it. The route
+// gets it stripped by URLFormat middleware, the handler drops it otherwise
+const calendarFeedExt = ".ics"
+
+type CalendarFeedRequest struct {
+	Token string `get:"token, true" validate:"required,max=64"`
+}
+
+type CalendarTokenResponse struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+// CalendarToken issues new secret token of the calendar feed,
+// the previous feed url stops working
+func (a *API) CalendarToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.calendar.CalendarToken"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		token, err := a.CalendarService.CalendarToken(r.Context(), uid)
+		if err != nil {
+			var calendarErr *calendar.Error
+			if errors.As(err, &calendarErr) {
+				log.Warn("calendar error", sl.Err(err))
+				response.CalendarError(w, calendarErr)
+				return
+			}
+			log.Error("failed to issue calendar token", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, CalendarTokenResponse{
+			Token: token,
+			Path:  "/calendar/" + token + calendarFeedExt,
+		})
+	}
+}
+
+// CalendarFeed renders upcoming pc orders as iCalendar, it is
+// authorized by the secret token in the url instead of jwt
+func (a *API) CalendarFeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.calendar.CalendarFeed"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[CalendarFeedRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		feed, err := a.CalendarService.Feed(r.Context(), strings.TrimSuffix(req.Token, calendarFeedExt))
+		if err != nil {
+			var calendarErr *calendar.Error
+			if errors.As(err, &calendarErr) {
+				log.Warn("calendar error", sl.Err(err))
+				response.CalendarError(w, calendarErr)
+				return
+			}
+			log.Error("failed to render calendar feed", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="pcclub.ics"`)
+		w.Header().Set("Cache-Control", "private, max-age=300")
+		if _, err := w.Write(feed); err != nil {
+			log.Error("failed to write calendar feed", sl.Err(err))
+		}
+	}
+}
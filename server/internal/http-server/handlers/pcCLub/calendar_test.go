@@ -0,0 +1,72 @@
+package pcCLub
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/services/pcClub/calendar"
+	"testing"
+)
+
+const testCalendarToken = "secret-token"
+
+type calendarServiceStub struct{}
+
+func (calendarServiceStub) CalendarToken(context.Context, int64) (string, error) {
+	return testCalendarToken, nil
+}
+
+func (calendarServiceStub) Feed(_ context.Context, token string) ([]byte, error) {
+	if token != testCalendarToken {
+		return nil, calendar.ErrNotFound
+	}
+	return []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil
+}
+
+func TestCalendarFeedServesIssuedPath(t *testing.T) {
+	api := &API{
+		Log:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		CalendarService: calendarServiceStub{},
+	}
+
+	tests := []struct {
+		name       string
+		middleware []func(http.Handler) http.Handler
+	}{
+		{name: "with url format", middleware: []func(http.Handler) http.Handler{middleware.URLFormat}},
+		{name: "without url format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := chi.NewRouter()
+			r.Use(tt.middleware...)
+			r.Get("/calendar/{token}", api.CalendarFeed())
+
+			tokenReq := httptest.NewRequest(http.MethodPost, "/calendar-token", nil)
+			tokenReq = tokenReq.WithContext(context.WithValue(tokenReq.Context(), "uid", int64(1)))
+			tokenRec := httptest.NewRecorder()
+			api.CalendarToken()(tokenRec, tokenReq)
+
+			var issued CalendarTokenResponse
+			if err := json.NewDecoder(tokenRec.Body).Decode(&issued); err != nil {
+				t.Fatalf("failed to decode token response: %v", err)
+			}
+
+			feedRec := httptest.NewRecorder()
+			r.ServeHTTP(feedRec, httptest.NewRequest(http.MethodGet, issued.Path, nil))
+
+			if feedRec.Code != http.StatusOK {
+				t.Fatalf("GET %s: status %d, body %q", issued.Path, feedRec.Code, feedRec.Body.String())
+			}
+			if got := feedRec.Header().Get("Content-Type"); got != "text/calendar; charset=utf-8" {
+				t.Errorf("unexpected content type %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/api/static"
+	"server/internal/lib/images"
+	"server/internal/models"
+	"server/internal/services/pcClub/dishImage"
+)
+
+const dishImageFormField = "image"
+
+type DishImagesRequest struct {
+	DishId int64 `get:"dish-id,true" validate:"required,min=1"`
+}
+
+// UploadDishImageRequest is sent in query, the image itself
+// is the image field of multipart form
+type UploadDishImageRequest struct {
+	DishId int64 `get:"dish-id" validate:"required,min=1"`
+	IsMain bool  `get:"main"`
+}
+
+type SetMainDishImageRequest struct {
+	DishImageId int64 `json:"dish_image_id" validate:"required,min=1"`
+}
+
+type DeleteDishImageRequest struct {
+	DishImageId int64 `json:"dish_image_id" validate:"required,min=1"`
+}
+
+// DishImageResponse is a dish image with its resized and WebP variants
+type DishImageResponse struct {
+	models.DishImage
+	Sources images.Set `json:"sources"`
+}
+
+// DishResponse is a dish with variants of its images
+type DishResponse struct {
+	models.Dish
+	DishImages []DishImageResponse `json:"dish_images"`
+}
+
+func dishImageResponse(dishImage models.DishImage) DishImageResponse {
+	return DishImageResponse{
+		DishImage: dishImage,
+		Sources:   images.Sources(dishImage.Path, dishImage.Width, dishImage.Height),
+	}
+}
+
+func dishImageResponses(dishImages []models.DishImage) []DishImageResponse {
+	res := make([]DishImageResponse, 0, len(dishImages))
+	for _, dishImage := range dishImages {
+		res = append(res, dishImageResponse(dishImage))
+	}
+	return res
+}
+
+func dishResponse(dish models.Dish) DishResponse {
+	return DishResponse{
+		Dish:       dish,
+		DishImages: dishImageResponses(dish.DishImages),
+	}
+}
+
+// DishImageFiles serves uploaded dish images
+func (a *API) DishImageFiles() http.Handler {
+	cfg := a.Cfg.Images.Dishes
+	return static.Handler(cfg.UrlPath, cfg.Path, cfg.CacheMaxAge)
+}
+
+func (a *API) DishImages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishImage.DishImages"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[DishImagesRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		dishImages, err := a.DishImageService.DishImages(r.Context(), req.DishId)
+		if err != nil {
+			var imageErr *dishImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("dish image error", sl.Err(err))
+				response.DishImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to get dish images", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, dishImageResponses(dishImages))
+	}
+}
+
+func (a *API) UploadDishImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishImage.UploadDishImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[UploadDishImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		data, ok := request.FormFile(w, r, log, dishImageFormField, a.Cfg.Images.Dishes.MaxSize)
+		if !ok {
+			return
+		}
+
+		image, err := a.DishImageService.SaveDishImage(r.Context(), req.DishId, data, req.IsMain)
+		if err != nil {
+			var imageErr *dishImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("dish image error", sl.Err(err))
+				response.DishImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to save dish image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, dishImageResponse(image))
+	}
+}
+
+func (a *API) SetMainDishImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishImage.SetMainDishImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SetMainDishImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.DishImageService.SetMainDishImage(r.Context(), req.DishImageId); err != nil {
+			var imageErr *dishImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("dish image error", sl.Err(err))
+				response.DishImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to set main dish image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (a *API) DeleteDishImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishImage.DeleteDishImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeleteDishImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.DishImageService.DeleteDishImage(r.Context(), req.DishImageId); err != nil {
+			var imageErr *dishImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("dish image error", sl.Err(err))
+				response.DishImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to delete dish image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
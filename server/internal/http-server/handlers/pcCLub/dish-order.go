@@ -0,0 +1,155 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/services/pcClub/orderDish"
+)
+
+type DishOrderItem struct {
+	DishId int64 `json:"dish_id" validate:"required,min=1"`
+	Count  int16 `json:"count" validate:"required,min=1,max=100"`
+}
+
+type SaveOrderDishRequest struct {
+	Dishes    []DishOrderItem `json:"dishes" validate:"required,min=1,max=50,dive"`
+	PromoCode string          `json:"promo_code" validate:"omitempty,max=32"`
+}
+
+type SaveOrderDishResponse struct {
+	DishOrderId int64       `json:"dish_order_id"`
+	Cost        money.Money `json:"cost"`
+	PcOrderId   int64       `json:"pc_order_id,omitempty"`
+	Pickup      bool        `json:"pickup"`
+}
+
+func (a *API) OrderDishes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishOrder.OrderDishes"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		orders, err := a.DishOrderService.DishOrders(r.Context(), uid)
+		if err != nil {
+			var orderErr *orderDish.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("dish order error", sl.Err(err))
+				response.DishOrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to get dish orders", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, orders)
+	}
+}
+
+func (a *API) SaveOrderDish() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishOrder.SaveOrderDish"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SaveOrderDishRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		items := make([]orderDish.Item, 0, len(req.Dishes))
+		for _, dish := range req.Dishes {
+			items = append(items, orderDish.Item{
+				DishID: dish.DishId,
+				Count:  dish.Count,
+			})
+		}
+
+		order, err := a.DishOrderService.SaveDishOrder(r.Context(), uid, items, req.PromoCode)
+		if err != nil {
+			var orderErr *orderDish.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("dish order error", sl.Err(err))
+				response.DishOrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to save dish order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, SaveOrderDishResponse{
+			DishOrderId: order.DishOrderID,
+			Cost:        order.Cost,
+			PcOrderId:   order.PcOrderID,
+			Pickup:      order.PcOrderID == 0,
+		})
+	}
+}
+
+type KitchenChangeOrderDishStatusRequest struct {
+	DishOrderId int64  `json:"dish_order_id" validate:"required,min=1"`
+	Status      string `json:"status" validate:"required,oneof=cooking ready delivered cancelled"`
+}
+
+func (a *API) KitchenOrderDishes() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishOrder.KitchenOrderDishes"
+
+		log := a.log(op, r)
+
+		orders, err := a.DishOrderService.KitchenQueue(r.Context())
+		if err != nil {
+			var orderErr *orderDish.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("dish order error", sl.Err(err))
+				response.DishOrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to get kitchen queue", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, orders)
+	}
+}
+
+func (a *API) KitchenChangeOrderDishStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishOrder.KitchenChangeOrderDishStatus"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[KitchenChangeOrderDishStatusRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		order, err := a.DishOrderService.ChangeDishOrderStatus(r.Context(), uid, req.DishOrderId, req.Status)
+		if err != nil {
+			var orderErr *orderDish.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("dish order error", sl.Err(err))
+				response.DishOrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to change dish order status", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, order)
+	}
+}
@@ -0,0 +1,123 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/services/pcClub/dishStock"
+)
+
+type SaveDishStockRequest struct {
+	DishId int64 `json:"dish_id" validate:"required,min=1"`
+	Count  int   `json:"count" validate:"min=0"`
+}
+
+type RestockDishRequest struct {
+	DishId int64 `json:"dish_id" validate:"required,min=1"`
+	Count  int   `json:"count" validate:"required,min=1"`
+}
+
+type DeleteDishStockRequest struct {
+	DishId int64 `json:"dish_id" validate:"required,min=1"`
+}
+
+func (a *API) DishStocks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishStock.DishStocks"
+
+		log := a.log(op, r)
+
+		stocks, err := a.DishStockService.DishStocks(r.Context())
+		if err != nil {
+			var stockErr *dishStock.Error
+			if errors.As(err, &stockErr) {
+				log.Warn("dish stock error", sl.Err(err))
+				response.DishStockError(w, stockErr)
+				return
+			}
+			log.Error("failed to get dish stocks", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, stocks)
+	}
+}
+
+func (a *API) SaveDishStock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishStock.SaveDishStock"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SaveDishStockRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.DishStockService.SaveDishStock(r.Context(), req.DishId, req.Count); err != nil {
+			var stockErr *dishStock.Error
+			if errors.As(err, &stockErr) {
+				log.Warn("dish stock error", sl.Err(err))
+				response.DishStockError(w, stockErr)
+				return
+			}
+			log.Error("failed to save dish stock", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (a *API) RestockDish() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishStock.RestockDish"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[RestockDishRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.DishStockService.RestockDish(r.Context(), req.DishId, req.Count); err != nil {
+			var stockErr *dishStock.Error
+			if errors.As(err, &stockErr) {
+				log.Warn("dish stock error", sl.Err(err))
+				response.DishStockError(w, stockErr)
+				return
+			}
+			log.Error("failed to restock dish", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (a *API) DeleteDishStock() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.dishStock.DeleteDishStock"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeleteDishStockRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.DishStockService.DeleteDishStock(r.Context(), req.DishId); err != nil {
+			var stockErr *dishStock.Error
+			if errors.As(err, &stockErr) {
+				log.Warn("dish stock error", sl.Err(err))
+				response.DishStockError(w, stockErr)
+				return
+			}
+			log.Error("failed to delete dish stock", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"server/internal/lib/api/logger/sl"
 	"server/internal/lib/api/request"
 	"server/internal/lib/api/response"
+	"server/internal/lib/money"
 	"server/internal/models"
 	dishService "server/internal/services/pcClub/dish"
 )
@@ -21,19 +22,19 @@ type DishRequest struct {
 }
 
 type SaveDishRequest struct {
-	Name        string  `json:"name" validate:"required,max=255"`
-	Calories    int16   `json:"calories" validate:"required,min=0"`
-	Cost        float32 `json:"cost" validate:"required,min=0"`
-	Description string  `json:"description" validate:"omitempty"`
+	Name        string      `json:"name" validate:"required,max=255"`
+	Calories    int16       `json:"calories" validate:"required,min=0"`
+	Cost        money.Money `json:"cost" validate:"required,min=0"`
+	Description string      `json:"description" validate:"omitempty"`
 }
 
 type UpdateDishRequest struct {
-	DishId      int64   `json:"dish_id" validate:"required,min=1"`
-	StatusId    int64   `json:"status_id" validate:"omitempty,min=1"`
-	Name        string  `json:"name" validate:"omitempty,max=255"`
-	Calories    int16   `json:"calories" validate:"omitempty,min=0"`
-	Cost        float32 `json:"cost" validate:"omitempty,min=0"`
-	Description string  `json:"description" validate:"omitempty"`
+	DishId      int64       `json:"dish_id" validate:"required,min=1"`
+	StatusId    int64       `json:"status_id" validate:"omitempty,min=1"`
+	Name        string      `json:"name" validate:"omitempty,max=255"`
+	Calories    int16       `json:"calories" validate:"omitempty,min=0"`
+	Cost        money.Money `json:"cost" validate:"omitempty,min=0"`
+	Description string      `json:"description" validate:"omitempty"`
 }
 
 type DeleteDishRequest struct {
@@ -64,7 +65,12 @@ func (a *API) Dishes() http.HandlerFunc {
 			return
 		}
 
-		render.JSON(w, r, dishes)
+		res := make([]DishResponse, 0, len(dishes))
+		for _, dish := range dishes {
+			res = append(res, dishResponse(dish))
+		}
+
+		render.JSON(w, r, res)
 	}
 }
 
@@ -92,7 +98,7 @@ func (a *API) Dish() http.HandlerFunc {
 			return
 		}
 
-		render.JSON(w, r, dish)
+		render.JSON(w, r, dishResponse(dish))
 	}
 }
 
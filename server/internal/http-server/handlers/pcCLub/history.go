@@ -0,0 +1,50 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/services/pcClub/history"
+	"time"
+)
+
+type OrderHistoryRequest struct {
+	Status string    `get:"status" validate:"omitempty,max=50"`
+	From   time.Time `get:"from"`
+	To     time.Time `get:"to" validate:"omitempty,gtfield=From"`
+	Cursor string    `get:"cursor" validate:"omitempty,max=128"`
+	Limit  int       `get:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (a *API) OrderHistory() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.history.OrderHistory"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateGETRequest[OrderHistoryRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		page, err := a.HistoryService.History(r.Context(), uid, req.Status, req.From, req.To, req.Cursor, req.Limit)
+		if err != nil {
+			var historyErr *history.Error
+			if errors.As(err, &historyErr) {
+				log.Warn("history error", sl.Err(err))
+				response.HistoryError(w, historyErr)
+				return
+			}
+			log.Error("failed to get order history", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, page)
+	}
+}
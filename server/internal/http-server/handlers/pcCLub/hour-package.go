@@ -0,0 +1,233 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/hourPackage"
+)
+
+type HourPackagesRequest struct {
+	TypeId int64 `get:"type-id" validate:"omitempty,min=1"`
+}
+
+// SaveHourPackageRequest describes package of minutes usable from start
+// minute to end minute of a day, equal minutes mean any time of a day
+// and start minute greater than end minute means window passing midnight
+type SaveHourPackageRequest struct {
+	PcTypeID    int64       `json:"pc_type_id" validate:"required,min=1"`
+	Name        string      `json:"name" validate:"required,max=50"`
+	Description string      `json:"description" validate:"omitempty,max=255"`
+	Cost        money.Money `json:"cost" validate:"required,money_min=1.00"`
+	Minutes     int         `json:"minutes" validate:"required,min=1"`
+	StartMinute int16       `json:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int16       `json:"end_minute" validate:"min=0,max=1439"`
+}
+
+type UpdateHourPackageRequest struct {
+	PackageID   int64       `json:"id" validate:"required,min=1"`
+	PcTypeID    int64       `json:"pc_type_id" validate:"required,min=1"`
+	Name        string      `json:"name" validate:"required,max=50"`
+	Description string      `json:"description" validate:"omitempty,max=255"`
+	Cost        money.Money `json:"cost" validate:"required,money_min=1.00"`
+	Minutes     int         `json:"minutes" validate:"required,min=1"`
+	StartMinute int16       `json:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int16       `json:"end_minute" validate:"min=0,max=1439"`
+}
+
+type DeleteHourPackageRequest struct {
+	PackageID int64 `json:"hour_package_id" validate:"required,min=1"`
+}
+
+type BuyHourPackageRequest struct {
+	PackageID int64 `json:"hour_package_id" validate:"required,min=1"`
+}
+
+type BuyHourPackageResponse struct {
+	UserHourPackageID int64 `json:"user_hour_package_id"`
+}
+
+func (a *API) HourPackages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.HourPackages"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[HourPackagesRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		packages, err := a.HourPackageService.HourPackages(r.Context(), req.TypeId)
+		if err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to get hour packages", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, packages)
+	}
+}
+
+func (a *API) UserHourPackages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.UserHourPackages"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		packages, err := a.HourPackageService.UserHourPackages(r.Context(), uid)
+		if err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to get user hour packages", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, packages)
+	}
+}
+
+func (a *API) BuyHourPackage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.BuyHourPackage"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[BuyHourPackageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		id, err := a.HourPackageService.BuyHourPackage(r.Context(), uid, req.PackageID)
+		if err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to buy hour package", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, BuyHourPackageResponse{UserHourPackageID: id})
+	}
+}
+
+func (a *API) SaveHourPackage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.SaveHourPackage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SaveHourPackageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		pack := models.HourPackage{
+			PcTypeID:    req.PcTypeID,
+			Name:        req.Name,
+			Description: req.Description,
+			Cost:        req.Cost,
+			Minutes:     req.Minutes,
+			StartMinute: req.StartMinute,
+			EndMinute:   req.EndMinute,
+		}
+		if _, err := a.HourPackageService.SaveHourPackage(r.Context(), &pack); err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to save hour package", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pack)
+	}
+}
+
+func (a *API) UpdateHourPackage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.UpdateHourPackage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[UpdateHourPackageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		pack := models.HourPackage{
+			PcTypeID:    req.PcTypeID,
+			Name:        req.Name,
+			Description: req.Description,
+			Cost:        req.Cost,
+			Minutes:     req.Minutes,
+			StartMinute: req.StartMinute,
+			EndMinute:   req.EndMinute,
+		}
+		if err := a.HourPackageService.UpdateHourPackage(r.Context(), req.PackageID, &pack); err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to update hour package", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pack)
+	}
+}
+
+func (a *API) DeleteHourPackage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.hourPackage.DeleteHourPackage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeleteHourPackageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.HourPackageService.DeleteHourPackage(r.Context(), req.PackageID); err != nil {
+			var packageErr *hourPackage.Error
+			if ok := errors.As(err, &packageErr); ok {
+				log.Warn("hour package error", sl.Err(err))
+				response.HourPackageError(w, packageErr)
+				return
+			}
+			log.Error("failed to delete hour package", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
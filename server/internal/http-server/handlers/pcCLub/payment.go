@@ -0,0 +1,199 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/services/pcClub/payment"
+)
+
+const defaultPaymentsLimit = 50
+
+type TopUpRequest struct {
+	Amount money.Money `json:"amount" validate:"required,money_min=1.00"`
+}
+
+type PaymentsRequest struct {
+	Limit  int `get:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int `get:"offset" validate:"omitempty,min=0"`
+}
+
+type PaymentRequest struct {
+	PaymentId int64 `get:"payment-id,true" validate:"required,min=1"`
+}
+
+type PaymentWebhookResponse struct {
+	PaymentId int64  `json:"payment_id"`
+	Status    string `json:"status"`
+	Applied   bool   `json:"applied"`
+}
+
+type AdminRefundPaymentRequest struct {
+	PaymentId int64 `json:"payment_id" validate:"required,min=1"`
+}
+
+func (a *API) TopUp() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.payment.TopUp"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[TopUpRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		created, err := a.PaymentService.TopUp(r.Context(), uid, req.Amount)
+		if err != nil {
+			var paymentErr *payment.Error
+			if errors.As(err, &paymentErr) {
+				log.Warn("payment error", sl.Err(err))
+				response.PaymentError(w, paymentErr)
+				return
+			}
+			log.Error("failed to top up", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, created)
+	}
+}
+
+func (a *API) Payments() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.payment.Payments"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateGETRequest[PaymentsRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultPaymentsLimit
+		}
+
+		list, err := a.PaymentService.Payments(r.Context(), uid, limit, req.Offset)
+		if err != nil {
+			var paymentErr *payment.Error
+			if errors.As(err, &paymentErr) {
+				log.Warn("payment error", sl.Err(err))
+				response.PaymentError(w, paymentErr)
+				return
+			}
+			log.Error("failed to get payments", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, list)
+	}
+}
+
+func (a *API) Payment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.payment.Payment"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateGETRequest[PaymentRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		found, err := a.PaymentService.Payment(r.Context(), uid, req.PaymentId)
+		if err != nil {
+			var paymentErr *payment.Error
+			if errors.As(err, &paymentErr) {
+				log.Warn("payment error", sl.Err(err))
+				response.PaymentError(w, paymentErr)
+				return
+			}
+			log.Error("failed to get payment", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, found)
+	}
+}
+
+// PaymentWebhook receives payment results from the provider,
+// any status except 200 makes the provider deliver the result again
+func (a *API) PaymentWebhook() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.payment.PaymentWebhook"
+
+		log := a.log(op, r)
+
+		completed, applied, err := a.PaymentService.HandleWebhook(r.Context(), r)
+		if err != nil {
+			var paymentErr *payment.Error
+			if errors.As(err, &paymentErr) {
+				log.Warn("payment webhook error", sl.Err(err))
+				response.PaymentError(w, paymentErr)
+				return
+			}
+			log.Error("failed to handle payment webhook", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		log.Info(
+			"payment webhook handled",
+			slog.Int64("payment_id", completed.PaymentID),
+			slog.String("status", completed.Status),
+			slog.Bool("applied", applied),
+		)
+
+		render.JSON(w, r, PaymentWebhookResponse{
+			PaymentId: completed.PaymentID,
+			Status:    completed.Status,
+			Applied:   applied,
+		})
+	}
+}
+
+func (a *API) AdminRefundPayment() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.payment.AdminRefundPayment"
+
+		log := a.log(op, r)
+
+		adminID := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminRefundPaymentRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		refunded, err := a.PaymentService.RefundPayment(r.Context(), adminID, req.PaymentId)
+		if err != nil {
+			var paymentErr *payment.Error
+			if errors.As(err, &paymentErr) {
+				log.Warn("payment error", sl.Err(err))
+				response.PaymentError(w, paymentErr)
+				return
+			}
+			log.Error("failed to refund payment", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, refunded)
+	}
+}
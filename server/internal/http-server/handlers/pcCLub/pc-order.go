@@ -1,24 +1,407 @@
 package pcCLub
 
 import (
+	"errors"
+	"github.com/go-chi/render"
 	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/services/pcClub/orderPc"
+	"strings"
 	"time"
 )
 
-type OrderPcsRequest struct {
-}
+const defaultAdminOrdersLimit = 50
 
 type SaveOrderPcRequest struct {
 	PcId      int64     `json:"pc_id" validate:"required,min=1"`
 	StartTime time.Time `json:"start_time" validate:"required,min=1"`
 	Duration  int16     `json:"duration" validate:"required,min=1"`
+	PromoCode string    `json:"promo_code" validate:"omitempty,max=32"`
 }
 type SaveOrderPcResponse struct {
 	Code string `json:"code"`
 }
 
+type QuoteOrderPcRequest struct {
+	PcId      int64     `get:"pc-id" validate:"required_without=TypeId,omitempty,min=1"`
+	TypeId    int64     `get:"type-id" validate:"omitempty,min=1"`
+	StartTime time.Time `get:"start-time" validate:"required"`
+	Duration  int16     `get:"duration" validate:"required,min=1"`
+	PromoCode string    `get:"promo-code" validate:"omitempty,max=32"`
+}
+
+type CheckInOrderPcRequest struct {
+	PcId int64  `json:"pc_id" validate:"required,min=1"`
+	Code string `json:"code" validate:"required,max=16"`
+}
+
+type CancelOrderPcRequest struct {
+	PcOrderId int64 `json:"pc_order_id" validate:"required,min=1"`
+}
+
+type AdminCancelOrderPcRequest struct {
+	PcOrderId  int64 `json:"pc_order_id" validate:"required,min=1"`
+	FullRefund bool  `json:"full_refund"`
+}
+
+type CancelOrderPcResponse struct {
+	Refund money.Money `json:"refund"`
+}
+
+type AdminOrderPcsRequest struct {
+	RoomId int64     `get:"room-id" validate:"omitempty,min=1"`
+	PcId   int64     `get:"pc-id" validate:"omitempty,min=1"`
+	UserId int64     `get:"user-id" validate:"omitempty,min=1"`
+	Status string    `get:"status" validate:"omitempty,max=50"`
+	From   time.Time `get:"from"`
+	To     time.Time `get:"to" validate:"omitempty,gtfield=From"`
+	Limit  int       `get:"limit" validate:"omitempty,min=1,max=500"`
+	Offset int       `get:"offset" validate:"omitempty,min=0"`
+}
+
+type AdminMoveOrderPcRequest struct {
+	PcOrderId int64     `json:"pc_order_id" validate:"required,min=1"`
+	PcId      int64     `json:"pc_id" validate:"required,min=1"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+}
+
+type ExtendOrderPcRequest struct {
+	PcOrderId int64 `json:"pc_order_id" validate:"required,min=1"`
+	Minutes   int16 `json:"minutes" validate:"required,min=1"`
+}
+
+type ExtendOrderPcResponse struct {
+	Cost money.Money `json:"cost"`
+}
+
+type EndOrderPcRequest struct {
+	PcOrderId int64 `json:"pc_order_id" validate:"required,min=1"`
+}
+
+type EndOrderPcResponse struct {
+	Refund money.Money `json:"refund"`
+}
+
 func (a *API) OrderPcs() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		const op = "handlers.pc-orderPc.OrderPc"
+		const op = "handlers.pcClub.pcOrder.OrderPcs"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		orders, err := a.OrderService.PcOrders(r.Context(), uid)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to get pc orders", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, orders)
+	}
+}
+
+func (a *API) SaveOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.SaveOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SaveOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		uid := request.MustUID(r)
+
+		code, err := a.OrderService.SavePcOrder(r.Context(), uid, req.PcId, req.StartTime, req.Duration, req.PromoCode)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to save pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, SaveOrderPcResponse{
+			Code: code,
+		})
+	}
+}
+
+func (a *API) QuoteOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.QuoteOrderPc"
+
+		log := a.log(op, r)
+
+		uid := request.MustUID(r)
+
+		req, ok := request.DecodeAndValidateGETRequest[QuoteOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		quote, err := a.OrderService.QuotePcOrder(
+			r.Context(),
+			uid,
+			req.PcId,
+			req.TypeId,
+			req.StartTime,
+			req.Duration,
+			req.PromoCode,
+		)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to quote pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, quote)
+	}
+}
+
+func (a *API) CheckInOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.CheckInOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[CheckInOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		order, err := a.OrderService.CheckInPcOrder(r.Context(), req.PcId, strings.ToUpper(req.Code))
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to check in pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, order)
+	}
+}
+
+func (a *API) CancelOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.CancelOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[CancelOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		uid := request.MustUID(r)
+
+		refund, err := a.OrderService.CancelPcOrder(r.Context(), uid, req.PcOrderId)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to cancel pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, CancelOrderPcResponse{
+			Refund: refund,
+		})
+	}
+}
+
+func (a *API) AdminCancelOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.AdminCancelOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminCancelOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		adminID := request.MustUID(r)
+
+		refund, err := a.OrderService.CancelPcOrderByAdmin(r.Context(), adminID, req.PcOrderId, req.FullRefund)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to cancel pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, CancelOrderPcResponse{
+			Refund: refund,
+		})
+	}
+}
+
+func (a *API) ExtendOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.ExtendOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[ExtendOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		uid := request.MustUID(r)
+
+		cost, err := a.OrderService.ExtendPcOrder(r.Context(), uid, req.PcOrderId, req.Minutes)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to extend pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, ExtendOrderPcResponse{
+			Cost: cost,
+		})
+	}
+}
+
+func (a *API) EndOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.EndOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[EndOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		uid := request.MustUID(r)
+
+		refund, err := a.OrderService.EndPcOrder(r.Context(), uid, req.PcOrderId)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to end pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, EndOrderPcResponse{
+			Refund: refund,
+		})
+	}
+}
+
+func (a *API) AdminOrderPcs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.AdminOrderPcs"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[AdminOrderPcsRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = defaultAdminOrdersLimit
+		}
+
+		report, err := a.OrderService.PcOrdersReport(
+			r.Context(),
+			req.RoomId,
+			req.PcId,
+			req.UserId,
+			req.Status,
+			req.From,
+			req.To,
+			limit,
+			req.Offset,
+		)
+		if err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to get pc orders report", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, report)
+	}
+}
+
+func (a *API) AdminMoveOrderPc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcOrder.AdminMoveOrderPc"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[AdminMoveOrderPcRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		adminID := request.MustUID(r)
+
+		if err := a.OrderService.MovePcOrder(r.Context(), adminID, req.PcOrderId, req.PcId, req.StartTime); err != nil {
+			var orderErr *orderPc.Error
+			if errors.As(err, &orderErr) {
+				log.Warn("pc order error", sl.Err(err))
+				response.OrderError(w, orderErr)
+				return
+			}
+			log.Error("failed to move pc order", sl.Err(err))
+			response.Internal(w)
+			return
+		}
 	}
 }
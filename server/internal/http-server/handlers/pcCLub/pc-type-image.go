@@ -0,0 +1,210 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/api/static"
+	"server/internal/lib/images"
+	"server/internal/models"
+	"server/internal/services/pcClub/pcTypeImage"
+)
+
+const pcTypeImageFormField = "image"
+
+type PcTypeImagesRequest struct {
+	TypeId int64 `get:"type-id,true" validate:"required,min=1"`
+}
+
+// UploadPcTypeImageRequest is sent in query, the image itself
+// is the image field of multipart form
+type UploadPcTypeImageRequest struct {
+	TypeId int64 `get:"type-id" validate:"required,min=1"`
+	IsMain bool  `get:"main"`
+}
+
+// ReorderPcTypeImagesRequest lists every image of the pc type in the new order
+type ReorderPcTypeImagesRequest struct {
+	TypeId         int64   `json:"pc_type_id" validate:"required,min=1"`
+	PcTypeImageIds []int64 `json:"pc_type_image_ids" validate:"required,min=1,dive,min=1"`
+}
+
+type SetMainPcTypeImageRequest struct {
+	PcTypeImageId int64 `json:"pc_type_image_id" validate:"required,min=1"`
+}
+
+type DeletePcTypeImageRequest struct {
+	PcTypeImageId int64 `json:"pc_type_image_id" validate:"required,min=1"`
+}
+
+// PcTypeImageResponse is a pc type image with its resized and WebP variants
+type PcTypeImageResponse struct {
+	models.PcTypeImage
+	Sources images.Set `json:"sources"`
+}
+
+// PcTypeResponse is a pc type with variants of its images
+type PcTypeResponse struct {
+	models.PcType
+	PcTypeImages []PcTypeImageResponse `json:"pc_type_images"`
+}
+
+func pcTypeImageResponse(typeImage models.PcTypeImage) PcTypeImageResponse {
+	return PcTypeImageResponse{
+		PcTypeImage: typeImage,
+		Sources:     images.Sources(typeImage.Path, typeImage.Width, typeImage.Height),
+	}
+}
+
+func pcTypeImageResponses(typeImages []models.PcTypeImage) []PcTypeImageResponse {
+	res := make([]PcTypeImageResponse, 0, len(typeImages))
+	for _, typeImage := range typeImages {
+		res = append(res, pcTypeImageResponse(typeImage))
+	}
+	return res
+}
+
+// PcTypeImageFiles serves uploaded pc type images
+func (a *API) PcTypeImageFiles() http.Handler {
+	cfg := a.Cfg.Images.PCS
+	return static.Handler(cfg.UrlPath, cfg.Path, cfg.CacheMaxAge)
+}
+
+func (a *API) PcTypeImages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeImage.PcTypeImages"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[PcTypeImagesRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		typeImages, err := a.PcTypeImageService.PcTypeImages(r.Context(), req.TypeId)
+		if err != nil {
+			var imageErr *pcTypeImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("pc type image error", sl.Err(err))
+				response.PcTypeImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to get pc type images", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pcTypeImageResponses(typeImages))
+	}
+}
+
+func (a *API) UploadPcTypeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeImage.UploadPcTypeImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[UploadPcTypeImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		data, ok := request.FormFile(w, r, log, pcTypeImageFormField, a.Cfg.Images.PCS.MaxSize)
+		if !ok {
+			return
+		}
+
+		image, err := a.PcTypeImageService.SavePcTypeImage(r.Context(), req.TypeId, data, req.IsMain)
+		if err != nil {
+			var imageErr *pcTypeImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("pc type image error", sl.Err(err))
+				response.PcTypeImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to save pc type image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pcTypeImageResponse(image))
+	}
+}
+
+func (a *API) ReorderPcTypeImages() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeImage.ReorderPcTypeImages"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[ReorderPcTypeImagesRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.PcTypeImageService.ReorderPcTypeImages(r.Context(), req.TypeId, req.PcTypeImageIds); err != nil {
+			var imageErr *pcTypeImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("pc type image error", sl.Err(err))
+				response.PcTypeImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to reorder pc type images", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (a *API) SetMainPcTypeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeImage.SetMainPcTypeImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SetMainPcTypeImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.PcTypeImageService.SetMainPcTypeImage(r.Context(), req.PcTypeImageId); err != nil {
+			var imageErr *pcTypeImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("pc type image error", sl.Err(err))
+				response.PcTypeImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to set main pc type image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (a *API) DeletePcTypeImage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeImage.DeletePcTypeImage"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeletePcTypeImageRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.PcTypeImageService.DeletePcTypeImage(r.Context(), req.PcTypeImageId); err != nil {
+			var imageErr *pcTypeImage.Error
+			if errors.As(err, &imageErr) {
+				log.Warn("pc type image error", sl.Err(err))
+				response.PcTypeImageError(w, imageErr)
+				return
+			}
+			log.Error("failed to delete pc type image", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
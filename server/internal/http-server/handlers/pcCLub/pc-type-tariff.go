@@ -0,0 +1,165 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/tariff"
+)
+
+type PcTypeTariffsRequest struct {
+	TypeId int64 `validate:"required,number,min=1" get:"type-id, true"`
+}
+
+// SavePcTypeTariffRequest describes hour cost acting from start minute
+// to end minute of a day on weekdays. Weekdays is a bit mask with Sunday
+// as the lowest bit, equal minutes mean the whole day and start minute
+// greater than end minute means range passing midnight
+type SavePcTypeTariffRequest struct {
+	PcTypeID    int64       `json:"pc_type_id" validate:"required,min=1"`
+	Name        string      `json:"name" validate:"required,max=50"`
+	HourCost    money.Money `json:"hour_cost" validate:"min=0"`
+	StartMinute int16       `json:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int16       `json:"end_minute" validate:"min=0,max=1439"`
+	Weekdays    int16       `json:"weekdays" validate:"required,min=1,max=127"`
+}
+
+type UpdatePcTypeTariffRequest struct {
+	TariffID    int64       `json:"id" validate:"required,min=1"`
+	Name        string      `json:"name" validate:"required,max=50"`
+	HourCost    money.Money `json:"hour_cost" validate:"min=0"`
+	StartMinute int16       `json:"start_minute" validate:"min=0,max=1439"`
+	EndMinute   int16       `json:"end_minute" validate:"min=0,max=1439"`
+	Weekdays    int16       `json:"weekdays" validate:"required,min=1,max=127"`
+}
+
+type DeletePcTypeTariffRequest struct {
+	TariffID int64 `json:"pc_type_tariff_id" validate:"required,min=1"`
+}
+
+func (a *API) PcTypeTariffs() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeTariff.PcTypeTariffs"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[PcTypeTariffsRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		tariffs, err := a.TariffService.PcTypeTariffs(r.Context(), req.TypeId)
+		if err != nil {
+			var tariffErr *tariff.Error
+			if ok := errors.As(err, &tariffErr); ok {
+				log.Warn("tariff error", sl.Err(err))
+				response.TariffError(w, tariffErr)
+				return
+			}
+			log.Error("failed to get pc type tariffs", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, tariffs)
+	}
+}
+
+func (a *API) SavePcTypeTariff() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeTariff.SavePcTypeTariff"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SavePcTypeTariffRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		pcTypeTariff := models.PcTypeTariff{
+			PcTypeID:    req.PcTypeID,
+			Name:        req.Name,
+			HourCost:    req.HourCost,
+			StartMinute: req.StartMinute,
+			EndMinute:   req.EndMinute,
+			Weekdays:    req.Weekdays,
+		}
+		if _, err := a.TariffService.SavePcTypeTariff(r.Context(), &pcTypeTariff); err != nil {
+			var tariffErr *tariff.Error
+			if ok := errors.As(err, &tariffErr); ok {
+				log.Warn("tariff error", sl.Err(err))
+				response.TariffError(w, tariffErr)
+				return
+			}
+			log.Error("failed to save pc type tariff", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pcTypeTariff)
+	}
+}
+
+func (a *API) UpdatePcTypeTariff() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeTariff.UpdatePcTypeTariff"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[UpdatePcTypeTariffRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		pcTypeTariff := models.PcTypeTariff{
+			Name:        req.Name,
+			HourCost:    req.HourCost,
+			StartMinute: req.StartMinute,
+			EndMinute:   req.EndMinute,
+			Weekdays:    req.Weekdays,
+		}
+		if err := a.TariffService.UpdatePcTypeTariff(r.Context(), req.TariffID, &pcTypeTariff); err != nil {
+			var tariffErr *tariff.Error
+			if ok := errors.As(err, &tariffErr); ok {
+				log.Warn("tariff error", sl.Err(err))
+				response.TariffError(w, tariffErr)
+				return
+			}
+			log.Error("failed to update pc type tariff", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, pcTypeTariff)
+	}
+}
+
+func (a *API) DeletePcTypeTariff() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pcTypeTariff.DeletePcTypeTariff"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeletePcTypeTariffRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.TariffService.DeletePcTypeTariff(r.Context(), req.TariffID); err != nil {
+			var tariffErr *tariff.Error
+			if ok := errors.As(err, &tariffErr); ok {
+				log.Warn("tariff error", sl.Err(err))
+				response.TariffError(w, tariffErr)
+				return
+			}
+			log.Error("failed to delete pc type tariff", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
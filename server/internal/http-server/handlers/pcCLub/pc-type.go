@@ -7,6 +7,7 @@ import (
 	"server/internal/lib/api/logger/sl"
 	"server/internal/lib/api/request"
 	"server/internal/lib/api/response"
+	"server/internal/lib/money"
 	"server/internal/models"
 	"server/internal/services/pcClub/pc"
 )
@@ -21,24 +22,24 @@ type PcTypeRequest struct {
 }
 
 type SavePcTypeRequest struct {
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description" validate:"omitempty,max=255"`
-	HourCost    float32 `json:"hour_cost" validate:"required,min=1"`
-	ProcessorID int64   `json:"processor_id" validate:"required,min=1"`
-	VideoCardID int64   `json:"video_card_id" validate:"required,min=1"`
-	MonitorID   int64   `json:"monitor_id" validate:"required,min=1"`
-	RamID       int64   `json:"ram_id" validate:"required,min=1"`
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description" validate:"omitempty,max=255"`
+	HourCost    money.Money `json:"hour_cost" validate:"required,money_min=1.00"`
+	ProcessorID int64       `json:"processor_id" validate:"required,min=1"`
+	VideoCardID int64       `json:"video_card_id" validate:"required,min=1"`
+	MonitorID   int64       `json:"monitor_id" validate:"required,min=1"`
+	RamID       int64       `json:"ram_id" validate:"required,min=1"`
 }
 
 type UpdatePcTypeRequest struct {
-	TypeID      int64   `json:"id" validate:"required,numeric"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description" validate:"omitempty,max=255"`
-	HourCost    float32 `json:"hour_cost" validate:"required,min=1"`
-	ProcessorID int64   `json:"processor_id" validate:"required,min=1"`
-	VideoCardID int64   `json:"video_card_id" validate:"required,min=1"`
-	MonitorID   int64   `json:"monitor_id" validate:"required,min=1"`
-	RamID       int64   `json:"ram_id" validate:"required,min=1"`
+	TypeID      int64       `json:"id" validate:"required,numeric"`
+	Name        string      `json:"name" validate:"required"`
+	Description string      `json:"description" validate:"omitempty,max=255"`
+	HourCost    money.Money `json:"hour_cost" validate:"required,money_min=1.00"`
+	ProcessorID int64       `json:"processor_id" validate:"required,min=1"`
+	VideoCardID int64       `json:"video_card_id" validate:"required,min=1"`
+	MonitorID   int64       `json:"monitor_id" validate:"required,min=1"`
+	RamID       int64       `json:"ram_id" validate:"required,min=1"`
 }
 
 type DeletePcTypeRequest struct {
@@ -97,7 +98,10 @@ func (a *API) PcType() http.HandlerFunc {
 			return
 		}
 
-		render.JSON(w, r, pcType)
+		render.JSON(w, r, PcTypeResponse{
+			PcType:       pcType,
+			PcTypeImages: pcTypeImageResponses(pcType.PcTypeImages),
+		})
 	}
 }
 
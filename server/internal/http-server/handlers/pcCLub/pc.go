@@ -9,6 +9,7 @@ import (
 	"server/internal/lib/api/response"
 	"server/internal/models"
 	"server/internal/services/pcClub/pc"
+	"time"
 )
 
 type PcsRequest struct {
@@ -16,6 +17,13 @@ type PcsRequest struct {
 	IsAvailable bool  `validate:"omitempty,boolean" get:"is-available"`
 }
 
+type PcsTimelineRequest struct {
+	TypeId int64     `get:"type-id" validate:"required_without=RoomId,omitempty,min=1"`
+	RoomId int64     `get:"room-id" validate:"omitempty,min=1"`
+	From   time.Time `get:"from" validate:"required"`
+	To     time.Time `get:"to" validate:"required,gtfield=From"`
+}
+
 type SavePcRequest struct {
 	TypeId      int64  `json:"type_id" validate:"required,numeric"`
 	RoomId      int64  `json:"room_id" validate:"required,numeric"`
@@ -66,6 +74,34 @@ func (a *API) Pcs() http.HandlerFunc {
 	}
 }
 
+func (a *API) PcsTimeline() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.pc.PcsTimeline"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateGETRequest[PcsTimelineRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		timelines, err := a.PcService.PcsTimeline(r.Context(), req.TypeId, req.RoomId, req.From, req.To)
+		if err != nil {
+			var pcErr *pc.Error
+			if ok := errors.As(err, &pcErr); ok {
+				log.Warn("pc error", sl.Err(err))
+				response.PcError(w, pcErr)
+				return
+			}
+			log.Error("failed to get pcs timeline", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, timelines)
+	}
+}
+
 func (a *API) SavePc() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.pcClub.pc.SavePc"
@@ -0,0 +1,168 @@
+package pcCLub
+
+import (
+	"errors"
+	"github.com/go-chi/render"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/api/request"
+	"server/internal/lib/api/response"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/promotion"
+	"time"
+)
+
+// SavePromotionRequest describes percent or fixed amount discount, zero
+// limits mean unlimited usage. Empty pc type, room and dish lists do not
+// restrict the promotion scope
+type SavePromotionRequest struct {
+	Code           string      `json:"code" validate:"required,max=32"`
+	Name           string      `json:"name" validate:"required,max=50"`
+	Percent        int16       `json:"percent" validate:"required_without=Amount,omitempty,min=1,max=100"`
+	Amount         money.Money `json:"amount" validate:"omitempty,min=0"`
+	StartDate      time.Time   `json:"start_date" validate:"required"`
+	EndDate        time.Time   `json:"end_date" validate:"required,gtfield=StartDate"`
+	UsageLimit     int         `json:"usage_limit" validate:"min=0"`
+	UserUsageLimit int         `json:"user_usage_limit" validate:"min=0"`
+	PcTypeIDs      []int64     `json:"pc_type_ids" validate:"dive,min=1"`
+	PcRoomIDs      []int64     `json:"pc_room_ids" validate:"dive,min=1"`
+	DishIDs        []int64     `json:"dish_ids" validate:"dive,min=1"`
+}
+
+type UpdatePromotionRequest struct {
+	PromotionID int64 `json:"id" validate:"required,min=1"`
+	SavePromotionRequest
+}
+
+type DeletePromotionRequest struct {
+	PromotionID int64 `json:"promotion_id" validate:"required,min=1"`
+}
+
+func (a *API) Promotions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.promotion.Promotions"
+
+		log := a.log(op, r)
+
+		promotions, err := a.PromotionService.Promotions(r.Context())
+		if err != nil {
+			var promotionErr *promotion.Error
+			if ok := errors.As(err, &promotionErr); ok {
+				log.Warn("promotion error", sl.Err(err))
+				response.PromotionError(w, promotionErr)
+				return
+			}
+			log.Error("failed to get promotions", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, promotions)
+	}
+}
+
+func (a *API) SavePromotion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.promotion.SavePromotion"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[SavePromotionRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		promo := req.promotion()
+		if _, err := a.PromotionService.SavePromotion(r.Context(), &promo); err != nil {
+			var promotionErr *promotion.Error
+			if ok := errors.As(err, &promotionErr); ok {
+				log.Warn("promotion error", sl.Err(err))
+				response.PromotionError(w, promotionErr)
+				return
+			}
+			log.Error("failed to save promotion", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, promo)
+	}
+}
+
+func (a *API) UpdatePromotion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.promotion.UpdatePromotion"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[UpdatePromotionRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		promo := req.promotion()
+		if err := a.PromotionService.UpdatePromotion(r.Context(), req.PromotionID, &promo); err != nil {
+			var promotionErr *promotion.Error
+			if ok := errors.As(err, &promotionErr); ok {
+				log.Warn("promotion error", sl.Err(err))
+				response.PromotionError(w, promotionErr)
+				return
+			}
+			log.Error("failed to update promotion", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+
+		render.JSON(w, r, promo)
+	}
+}
+
+func (a *API) DeletePromotion() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.pcClub.promotion.DeletePromotion"
+
+		log := a.log(op, r)
+
+		req, ok := request.DecodeAndValidateJSONRequest[DeletePromotionRequest](w, r, log)
+		if !ok {
+			return
+		}
+
+		if err := a.PromotionService.DeletePromotion(r.Context(), req.PromotionID); err != nil {
+			var promotionErr *promotion.Error
+			if ok := errors.As(err, &promotionErr); ok {
+				log.Warn("promotion error", sl.Err(err))
+				response.PromotionError(w, promotionErr)
+				return
+			}
+			log.Error("failed to delete promotion", sl.Err(err))
+			response.Internal(w)
+			return
+		}
+	}
+}
+
+func (r *SavePromotionRequest) promotion() models.Promotion {
+	promo := models.Promotion{
+		Code:           r.Code,
+		Name:           r.Name,
+		Percent:        r.Percent,
+		Amount:         r.Amount,
+		StartDate:      r.StartDate,
+		EndDate:        r.EndDate,
+		UsageLimit:     r.UsageLimit,
+		UserUsageLimit: r.UserUsageLimit,
+	}
+	for _, id := range r.PcTypeIDs {
+		promo.PromotionPcTypes = append(promo.PromotionPcTypes, models.PromotionPcType{PcTypeID: id})
+	}
+	for _, id := range r.PcRoomIDs {
+		promo.PromotionPcRooms = append(promo.PromotionPcRooms, models.PromotionPcRoom{PcRoomID: id})
+	}
+	for _, id := range r.DishIDs {
+		promo.PromotionDishes = append(promo.PromotionDishes, models.PromotionDish{DishID: id})
+	}
+
+	return promo
+}
@@ -6,7 +6,14 @@ import (
 	"log/slog"
 	"net/http"
 	"server/internal/config"
+	"server/internal/lib/money"
 	"server/internal/models"
+	"server/internal/services/pcClub/balance"
+	"server/internal/services/pcClub/history"
+	"server/internal/services/pcClub/orderDish"
+	"server/internal/services/pcClub/orderPc"
+	"server/internal/services/pcClub/pc"
+	"time"
 )
 
 type AuthService interface {
@@ -72,6 +79,11 @@ type UserService interface {
 		ctx context.Context,
 		uid int64,
 	) (err error)
+
+	IsKitchen(
+		ctx context.Context,
+		uid int64,
+	) (err error)
 }
 
 type PcTypeService interface {
@@ -110,6 +122,14 @@ type PcService interface {
 		isAvailable bool,
 	) (pcs []models.Pc, err error)
 
+	PcsTimeline(
+		ctx context.Context,
+		typeID int64,
+		roomID int64,
+		from time.Time,
+		to time.Time,
+	) (timelines []pc.Timeline, err error)
+
 	SavePc(
 		ctx context.Context,
 		pc *models.Pc,
@@ -127,6 +147,109 @@ type PcService interface {
 	) (err error)
 }
 
+type TariffService interface {
+	PcTypeTariffs(
+		ctx context.Context,
+		typeID int64,
+	) (tariffs []models.PcTypeTariff, err error)
+
+	SavePcTypeTariff(
+		ctx context.Context,
+		tariff *models.PcTypeTariff,
+	) (id int64, err error)
+
+	UpdatePcTypeTariff(
+		ctx context.Context,
+		tariffID int64,
+		tariff *models.PcTypeTariff,
+	) (err error)
+
+	DeletePcTypeTariff(
+		ctx context.Context,
+		tariffID int64,
+	) (err error)
+}
+
+type HourPackageService interface {
+	HourPackages(
+		ctx context.Context,
+		typeID int64,
+	) (packages []models.HourPackage, err error)
+
+	UserHourPackages(
+		ctx context.Context,
+		uid int64,
+	) (packages []models.UserHourPackage, err error)
+
+	SaveHourPackage(
+		ctx context.Context,
+		hourPackage *models.HourPackage,
+	) (id int64, err error)
+
+	UpdateHourPackage(
+		ctx context.Context,
+		packageID int64,
+		hourPackage *models.HourPackage,
+	) (err error)
+
+	DeleteHourPackage(
+		ctx context.Context,
+		packageID int64,
+	) (err error)
+
+	BuyHourPackage(
+		ctx context.Context,
+		uid int64,
+		packageID int64,
+	) (id int64, err error)
+}
+
+type PromotionService interface {
+	Promotions(
+		ctx context.Context,
+	) (promotions []models.Promotion, err error)
+
+	SavePromotion(
+		ctx context.Context,
+		promotion *models.Promotion,
+	) (id int64, err error)
+
+	UpdatePromotion(
+		ctx context.Context,
+		promotionID int64,
+		promotion *models.Promotion,
+	) (err error)
+
+	DeletePromotion(
+		ctx context.Context,
+		promotionID int64,
+	) (err error)
+}
+
+type HistoryService interface {
+	History(
+		ctx context.Context,
+		uid int64,
+		status string,
+		from time.Time,
+		to time.Time,
+		cursor string,
+		limit int,
+	) (page history.Page, err error)
+}
+
+type CalendarService interface {
+	CalendarToken(
+		ctx context.Context,
+		uid int64,
+	) (token string, err error)
+
+	Feed(
+		ctx context.Context,
+		token string,
+	) (feed []byte, err error)
+}
+
 type PcRoomService interface {
 	PcRoom(
 		ctx context.Context,
@@ -319,20 +442,270 @@ type OrderService interface {
 	PcOrders(
 		ctx context.Context,
 		uid int64,
-	)
+	) (orders []models.PcOrder, err error)
+
+	SavePcOrder(
+		ctx context.Context,
+		uid int64,
+		pcID int64,
+		startTime time.Time,
+		duration int16,
+		promoCode string,
+	) (code string, err error)
+
+	QuotePcOrder(
+		ctx context.Context,
+		uid int64,
+		pcID int64,
+		typeID int64,
+		startTime time.Time,
+		duration int16,
+		promoCode string,
+	) (quote orderPc.Quote, err error)
+
+	CheckInPcOrder(
+		ctx context.Context,
+		pcID int64,
+		code string,
+	) (order models.PcOrder, err error)
+
+	CancelPcOrder(
+		ctx context.Context,
+		uid int64,
+		orderID int64,
+	) (refund money.Money, err error)
+
+	CancelPcOrderByAdmin(
+		ctx context.Context,
+		adminID int64,
+		orderID int64,
+		fullRefund bool,
+	) (refund money.Money, err error)
+
+	ExtendPcOrder(
+		ctx context.Context,
+		uid int64,
+		orderID int64,
+		minutes int16,
+	) (cost money.Money, err error)
+
+	EndPcOrder(
+		ctx context.Context,
+		uid int64,
+		orderID int64,
+	) (refund money.Money, err error)
+
+	PcOrdersReport(
+		ctx context.Context,
+		roomID int64,
+		pcID int64,
+		uid int64,
+		status string,
+		from time.Time,
+		to time.Time,
+		limit int,
+		offset int,
+	) (report orderPc.Report, err error)
+
+	MovePcOrder(
+		ctx context.Context,
+		adminID int64,
+		orderID int64,
+		pcID int64,
+		startTime time.Time,
+	) (err error)
+}
+
+type DishOrderService interface {
+	DishOrders(
+		ctx context.Context,
+		uid int64,
+	) (orders []models.DishOrder, err error)
+
+	SaveDishOrder(
+		ctx context.Context,
+		uid int64,
+		items []orderDish.Item,
+		promoCode string,
+	) (order models.DishOrder, err error)
+
+	KitchenQueue(
+		ctx context.Context,
+	) (orders []orderDish.KitchenOrder, err error)
+
+	ChangeDishOrderStatus(
+		ctx context.Context,
+		staffID int64,
+		orderID int64,
+		status string,
+	) (order orderDish.KitchenOrder, err error)
+}
+
+type DishStockService interface {
+	DishStocks(
+		ctx context.Context,
+	) (stocks []models.DishStock, err error)
+
+	SaveDishStock(
+		ctx context.Context,
+		dishID int64,
+		count int,
+	) (err error)
+
+	RestockDish(
+		ctx context.Context,
+		dishID int64,
+		count int,
+	) (err error)
+
+	DeleteDishStock(
+		ctx context.Context,
+		dishID int64,
+	) (err error)
+}
+
+type DishImageService interface {
+	DishImages(
+		ctx context.Context,
+		dishID int64,
+	) (images []models.DishImage, err error)
+
+	SaveDishImage(
+		ctx context.Context,
+		dishID int64,
+		data []byte,
+		isMain bool,
+	) (image models.DishImage, err error)
+
+	SetMainDishImage(
+		ctx context.Context,
+		imageID int64,
+	) (err error)
+
+	DeleteDishImage(
+		ctx context.Context,
+		imageID int64,
+	) (err error)
+}
+
+type PcTypeImageService interface {
+	PcTypeImages(
+		ctx context.Context,
+		typeID int64,
+	) (images []models.PcTypeImage, err error)
+
+	SavePcTypeImage(
+		ctx context.Context,
+		typeID int64,
+		data []byte,
+		isMain bool,
+	) (image models.PcTypeImage, err error)
+
+	ReorderPcTypeImages(
+		ctx context.Context,
+		typeID int64,
+		imageIDs []int64,
+	) (err error)
+
+	SetMainPcTypeImage(
+		ctx context.Context,
+		imageID int64,
+	) (err error)
+
+	DeletePcTypeImage(
+		ctx context.Context,
+		imageID int64,
+	) (err error)
+}
+
+type BalanceService interface {
+	Statement(
+		ctx context.Context,
+		uid int64,
+		limit int,
+		offset int,
+	) (statement balance.Statement, err error)
+
+	TopUpBalance(
+		ctx context.Context,
+		uid int64,
+		adminID int64,
+		amount money.Money,
+		comment string,
+	) (transaction models.BalanceTransaction, err error)
+
+	AdjustBalance(
+		ctx context.Context,
+		uid int64,
+		adminID int64,
+		amount money.Money,
+		comment string,
+	) (transaction models.BalanceTransaction, err error)
+
+	ReconcileBalance(
+		ctx context.Context,
+		uid int64,
+		adminID int64,
+	) (transaction models.BalanceTransaction, err error)
+}
+
+type PaymentService interface {
+	Enabled() bool
+
+	TopUp(
+		ctx context.Context,
+		uid int64,
+		amount money.Money,
+	) (payment models.Payment, err error)
+
+	Payments(
+		ctx context.Context,
+		uid int64,
+		limit int,
+		offset int,
+	) (payments []models.Payment, err error)
+
+	Payment(
+		ctx context.Context,
+		uid int64,
+		paymentID int64,
+	) (payment models.Payment, err error)
+
+	HandleWebhook(
+		ctx context.Context,
+		r *http.Request,
+	) (payment models.Payment, applied bool, err error)
+
+	RefundPayment(
+		ctx context.Context,
+		adminID int64,
+		paymentID int64,
+	) (payment models.Payment, err error)
 }
 
 type API struct {
-	Log               *slog.Logger
-	Cfg               *config.Config
-	UserService       UserService
-	AuthService       AuthService
-	PcTypeService     PcTypeService
-	PcService         PcService
-	PcRoomService     PcRoomService
-	ComponentsService ComponentsService
-	DishService       DishService
-	OrderService      OrderService
+	Log                *slog.Logger
+	Cfg                *config.Config
+	UserService        UserService
+	AuthService        AuthService
+	PcTypeService      PcTypeService
+	PcService          PcService
+	PcRoomService      PcRoomService
+	ComponentsService  ComponentsService
+	DishService        DishService
+	OrderService       OrderService
+	TariffService      TariffService
+	HourPackageService HourPackageService
+	PromotionService   PromotionService
+	HistoryService     HistoryService
+	CalendarService    CalendarService
+	DishOrderService   DishOrderService
+	DishStockService   DishStockService
+	DishImageService   DishImageService
+	PcTypeImageService PcTypeImageService
+	BalanceService     BalanceService
+	PaymentService     PaymentService
+	PaymentCheckout    http.Handler
 }
 
 func New(
@@ -345,17 +718,43 @@ func New(
 	pcRoomService PcRoomService,
 	componentsService ComponentsService,
 	dishService DishService,
+	orderService OrderService,
+	tariffService TariffService,
+	hourPackageService HourPackageService,
+	promotionService PromotionService,
+	historyService HistoryService,
+	calendarService CalendarService,
+	dishOrderService DishOrderService,
+	dishStockService DishStockService,
+	dishImageService DishImageService,
+	pcTypeImageService PcTypeImageService,
+	balanceService BalanceService,
+	paymentService PaymentService,
+	paymentCheckout http.Handler,
 ) *API {
 	return &API{
-		Log:               log,
-		Cfg:               cfg,
-		UserService:       userService,
-		AuthService:       authService,
-		PcTypeService:     pcTypeService,
-		PcService:         pcService,
-		PcRoomService:     pcRoomService,
-		ComponentsService: componentsService,
-		DishService:       dishService,
+		Log:                log,
+		Cfg:                cfg,
+		UserService:        userService,
+		AuthService:        authService,
+		PcTypeService:      pcTypeService,
+		PcService:          pcService,
+		PcRoomService:      pcRoomService,
+		ComponentsService:  componentsService,
+		DishService:        dishService,
+		OrderService:       orderService,
+		TariffService:      tariffService,
+		HourPackageService: hourPackageService,
+		PromotionService:   promotionService,
+		HistoryService:     historyService,
+		CalendarService:    calendarService,
+		DishOrderService:   dishOrderService,
+		DishStockService:   dishStockService,
+		DishImageService:   dishImageService,
+		PcTypeImageService: pcTypeImageService,
+		BalanceService:     balanceService,
+		PaymentService:     paymentService,
+		PaymentCheckout:    paymentCheckout,
 	}
 }
 
@@ -0,0 +1,21 @@
+package authKitchen
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"server/internal/http-server/middleware/auth/authRole"
+)
+
+type UserService interface {
+	IsKitchen(
+		ctx context.Context,
+		uid int64,
+	) (err error)
+}
+
+func AuthKitchen(log *slog.Logger, s authRole.AuthService, u UserService) func(next http.Handler) http.Handler {
+	const op = "middleware.auth.auth.authKitchen.AuthKitchen"
+
+	return authRole.AuthRole(log, op, "kitchen staff", s, u.IsKitchen)
+}
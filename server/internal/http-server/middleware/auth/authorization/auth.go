@@ -23,7 +23,7 @@ func Authorize(log *slog.Logger, s AuthService) func(next http.Handler) http.Han
 	return func(next http.Handler) http.Handler {
 		const op = "middleware.auth.authorization.Authorize"
 		fn := func(w http.ResponseWriter, r *http.Request) {
-			log = log.With(
+			log := log.With(
 				slog.String("operation", op),
 				slog.String("request_id", middleware.GetReqID(r.Context())),
 			)
@@ -0,0 +1,53 @@
+package request
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"server/internal/lib/api/logger/sl"
+)
+
+// formOverhead is room for multipart headers and boundaries over the file size
+const formOverhead = 1 << 20
+
+// FormFile reads the file sent in multipart form field, files larger
+// than maxSize bytes are rejected with 413 and missing file with 400
+func FormFile(
+	w http.ResponseWriter,
+	r *http.Request,
+	log *slog.Logger,
+	field string,
+	maxSize int64,
+) ([]byte, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+formOverhead)
+
+	file, _, err := r.FormFile(field)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			log.Warn("request body is too large", sl.Err(err))
+			http.Error(w, "file is too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+
+		log.Warn("no file in request", sl.Err(err))
+		http.Error(w, field+" file is required", http.StatusBadRequest)
+		return nil, false
+	}
+	defer func() { _ = file.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		log.Error("failed to read file", sl.Err(err))
+		http.Error(w, "failed to read file", http.StatusBadRequest)
+		return nil, false
+	}
+	if int64(len(data)) > maxSize {
+		log.Warn("file is too large")
+		http.Error(w, "file is too large", http.StatusRequestEntityTooLarge)
+		return nil, false
+	}
+
+	return data, true
+}
@@ -7,10 +7,18 @@ import (
 	"net/http"
 	"server/internal/lib/api/logger/sl"
 	"server/internal/lib/api/response"
+	"server/internal/lib/money"
 )
 
 func ValidateRequest[T any](w http.ResponseWriter, req any, log *slog.Logger) bool {
-	if err := validator.New().Struct(req); err != nil {
+	validate := validator.New()
+	if err := validate.RegisterValidation("money_min", moneyMin); err != nil {
+		log.Error("failed to register money validation", sl.Err(err))
+		response.Internal(w)
+		return false
+	}
+
+	if err := validate.Struct(req); err != nil {
 		var validError validator.ValidationErrors
 		if ok := errors.As(err, &validError); ok {
 			log.Warn("invalid request", sl.Err(err))
@@ -24,3 +32,20 @@ func ValidateRequest[T any](w http.ResponseWriter, req any, log *slog.Logger) bo
 	}
 	return true
 }
+
+// moneyMin checks that money field is not less than decimal amount in
+// the tag parameter, like money_min=1.00. Plain min and max on money
+// compare minor units
+func moneyMin(fl validator.FieldLevel) bool {
+	value, ok := fl.Field().Interface().(money.Money)
+	if !ok {
+		return false
+	}
+
+	limit, err := money.Parse(fl.Param())
+	if err != nil {
+		return false
+	}
+
+	return value >= limit
+}
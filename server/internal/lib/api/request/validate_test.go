@@ -0,0 +1,43 @@
+package request
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"server/internal/lib/money"
+	"testing"
+)
+
+type moneyRequest struct {
+	Cost money.Money `json:"cost" validate:"required,money_min=1.00"`
+	Tip  money.Money `json:"tip" validate:"min=0"`
+}
+
+func TestValidateRequestMoney(t *testing.T) {
+	tests := []struct {
+		name string
+		req  moneyRequest
+		want bool
+	}{
+		{name: "at minimum", req: moneyRequest{Cost: 100}, want: true},
+		{name: "above minimum", req: moneyRequest{Cost: 150, Tip: 1}, want: true},
+		{name: "below minimum", req: moneyRequest{Cost: 99}, want: false},
+		{name: "missing", req: moneyRequest{}, want: false},
+		{name: "negative", req: moneyRequest{Cost: -100}, want: false},
+		{name: "negative tip", req: moneyRequest{Cost: 100, Tip: -1}, want: false},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if got := ValidateRequest[moneyRequest](w, tt.req, log); got != tt.want {
+				t.Fatalf("ValidateRequest() = %v, want %v", got, tt.want)
+			}
+			if !tt.want && w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
@@ -7,11 +7,22 @@ import (
 	validator2 "server/internal/lib/api/validator"
 	"server/internal/lib/cookie"
 	"server/internal/services/pcClub/auth"
+	"server/internal/services/pcClub/balance"
+	"server/internal/services/pcClub/calendar"
 	"server/internal/services/pcClub/components"
 	"server/internal/services/pcClub/dish"
+	"server/internal/services/pcClub/dishImage"
+	"server/internal/services/pcClub/dishStock"
+	"server/internal/services/pcClub/history"
+	"server/internal/services/pcClub/hourPackage"
+	"server/internal/services/pcClub/orderDish"
 	"server/internal/services/pcClub/orderPc"
+	"server/internal/services/pcClub/payment"
 	"server/internal/services/pcClub/pc"
 	"server/internal/services/pcClub/pcRoom"
+	"server/internal/services/pcClub/pcTypeImage"
+	"server/internal/services/pcClub/promotion"
+	"server/internal/services/pcClub/tariff"
 	"server/internal/services/pcClub/user"
 )
 
@@ -46,8 +57,30 @@ func OrderError(w http.ResponseWriter, err *orderPc.Error) {
 	switch err.Code {
 	case orderPc.ErrNotFoundCode:
 		http.Error(w, err.Error(), http.StatusNotFound)
-	case orderPc.ErrAlreadyExistsCode, orderPc.ErrConstraintCode, orderPc.ErrReferenceNotExistsCode:
+	case orderPc.ErrAlreadyExistsCode, orderPc.ErrConstraintCode, orderPc.ErrReferenceNotExistsCode,
+		orderPc.ErrIllegalStatusCode, orderPc.ErrInvalidPromotionCode:
 		http.Error(w, err.Error(), http.StatusConflict)
+	case orderPc.ErrNotEnoughBalanceCode:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+	case orderPc.ErrAccessDeniedCode:
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		Internal(w)
+	}
+}
+
+func DishOrderError(w http.ResponseWriter, err *orderDish.Error) {
+	switch err.Code {
+	case orderDish.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case orderDish.ErrAlreadyExistsCode, orderDish.ErrConstraintCode, orderDish.ErrReferenceNotExistsCode,
+		orderDish.ErrIllegalStatusCode, orderDish.ErrInvalidPromotionCode, orderDish.ErrNotOrderableCode,
+		orderDish.ErrOutOfStockCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case orderDish.ErrNotEnoughBalanceCode:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+	case orderDish.ErrAccessDeniedCode:
+		http.Error(w, err.Error(), http.StatusForbidden)
 	default:
 		Internal(w)
 	}
@@ -77,6 +110,136 @@ func PcRoomError(w http.ResponseWriter, err *pcRoom.Error) {
 	}
 }
 
+func TariffError(w http.ResponseWriter, err *tariff.Error) {
+	switch err.Code {
+	case tariff.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case tariff.ErrAlreadyExistsCode, tariff.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
+func HourPackageError(w http.ResponseWriter, err *hourPackage.Error) {
+	switch err.Code {
+	case hourPackage.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case hourPackage.ErrAlreadyExistsCode, hourPackage.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case hourPackage.ErrNotEnoughBalanceCode:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+	default:
+		Internal(w)
+	}
+}
+
+func BalanceError(w http.ResponseWriter, err *balance.Error) {
+	switch err.Code {
+	case balance.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case balance.ErrNotEnoughBalanceCode:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+	case balance.ErrAlreadyExistsCode, balance.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
+func PaymentError(w http.ResponseWriter, err *payment.Error) {
+	switch err.Code {
+	case payment.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case payment.ErrInvalidSignatureCode:
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+	case payment.ErrInvalidEventCode:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case payment.ErrNotEnoughBalanceCode:
+		http.Error(w, err.Error(), http.StatusPaymentRequired)
+	case payment.ErrProviderCode:
+		http.Error(w, err.Error(), http.StatusBadGateway)
+	case payment.ErrAlreadyExistsCode, payment.ErrConstraintCode, payment.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
+func DishStockError(w http.ResponseWriter, err *dishStock.Error) {
+	switch err.Code {
+	case dishStock.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case dishStock.ErrAlreadyExistsCode, dishStock.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
+func DishImageError(w http.ResponseWriter, err *dishImage.Error) {
+	switch err.Code {
+	case dishImage.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case dishImage.ErrAlreadyExistsCode, dishImage.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case dishImage.ErrUnsupportedTypeCode:
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case dishImage.ErrTooLargeCode:
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	default:
+		Internal(w)
+	}
+}
+
+func PcTypeImageError(w http.ResponseWriter, err *pcTypeImage.Error) {
+	switch err.Code {
+	case pcTypeImage.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case pcTypeImage.ErrAlreadyExistsCode, pcTypeImage.ErrReferenceNotExistsCode, pcTypeImage.ErrConstraintCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	case pcTypeImage.ErrUnsupportedTypeCode:
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case pcTypeImage.ErrTooLargeCode:
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	default:
+		Internal(w)
+	}
+}
+
+func PromotionError(w http.ResponseWriter, err *promotion.Error) {
+	switch err.Code {
+	case promotion.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case promotion.ErrAlreadyExistsCode, promotion.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
+func HistoryError(w http.ResponseWriter, err *history.Error) {
+	switch err.Code {
+	case history.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case history.ErrInvalidCursorCode:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		Internal(w)
+	}
+}
+
+func CalendarError(w http.ResponseWriter, err *calendar.Error) {
+	switch err.Code {
+	case calendar.ErrNotFoundCode:
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case calendar.ErrAlreadyExistsCode, calendar.ErrReferenceNotExistsCode:
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		Internal(w)
+	}
+}
+
 func ComponentsError(w http.ResponseWriter, err *components.Error) {
 	switch err.Code {
 	case components.ErrNotFoundCode:
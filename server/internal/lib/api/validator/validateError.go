@@ -22,7 +22,7 @@ func ValidationError[T any](errs validator.ValidationErrors) string {
 			errMsgs = append(errMsgs, fmt.Sprintf("the field %s is required", field))
 		case "max":
 			errMsgs = append(errMsgs, fmt.Sprintf("the field %s is over maximum", field))
-		case "min":
+		case "min", "money_min":
 			errMsgs = append(errMsgs, fmt.Sprintf("the field %s is lover minimum", field))
 		default:
 			errMsgs = append(errMsgs, fmt.Sprintf("the field %s is not valid", field))
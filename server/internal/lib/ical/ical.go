@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	lineLength     = 75
+)
+
+// Event is a VEVENT of the calendar
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+}
+
+// Calendar renders events as RFC 5545 VCALENDAR with times in UTC
+func Calendar(prodID string, name string, events []Event) []byte {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN", "VCALENDAR")
+	writeLine(&buf, "VERSION", "2.0")
+	writeLine(&buf, "PRODID", prodID)
+	writeLine(&buf, "CALSCALE", "GREGORIAN")
+	writeLine(&buf, "METHOD", "PUBLISH")
+	writeLine(&buf, "X-WR-CALNAME", escape(name))
+
+	stamp := time.Now().UTC().Format(dateTimeLayout)
+	for _, event := range events {
+		writeLine(&buf, "BEGIN", "VEVENT")
+		writeLine(&buf, "UID", event.UID)
+		writeLine(&buf, "DTSTAMP", stamp)
+		writeLine(&buf, "DTSTART", event.Start.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "DTEND", event.End.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "SUMMARY", escape(event.Summary))
+		if event.Location != "" {
+			writeLine(&buf, "LOCATION", escape(event.Location))
+		}
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION", escape(event.Description))
+		}
+		writeLine(&buf, "END", "VEVENT")
+	}
+
+	writeLine(&buf, "END", "VCALENDAR")
+
+	return buf.Bytes()
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// escape escapes TEXT value special characters
+func escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// writeLine writes content line folded to 75 octets without
+// splitting multibyte characters, continuation lines start with space
+func writeLine(buf *bytes.Buffer, name string, value string) {
+	line := name + ":" + value
+
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = lineLength - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,107 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain text", want: "plain text"},
+		{in: `back\slash`, want: `back\\slash`},
+		{in: "a;b,c", want: `a\;b\,c`},
+		{in: "one\ntwo", want: `one\ntwo`},
+		{in: "one\r\ntwo", want: `one\ntwo`},
+		{in: `\n`, want: `\\n`},
+	}
+
+	for _, tt := range tests {
+		if got := escape(tt.in); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "short",
+			value: "value",
+			want:  "NAME:value\r\n",
+		},
+		{
+			name:  "exactly 75 octets",
+			value: strings.Repeat("a", 70),
+			want:  "NAME:" + strings.Repeat("a", 70) + "\r\n",
+		},
+		{
+			name:  "folded",
+			value: strings.Repeat("a", 71),
+			want:  "NAME:" + strings.Repeat("a", 70) + "\r\n a\r\n",
+		},
+		{
+			name:  "continuation lines hold 74 octets",
+			value: strings.Repeat("a", 70+74+1),
+			want:  "NAME:" + strings.Repeat("a", 70) + "\r\n " + strings.Repeat("a", 74) + "\r\n a\r\n",
+		},
+		{
+			name:  "multibyte character is not split",
+			value: strings.Repeat("a", 69) + "ж",
+			want:  "NAME:" + strings.Repeat("a", 69) + "\r\n ж\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeLine(&buf, "NAME", tt.value)
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("writeLine() = %q, want %q", got, tt.want)
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+				if len(line) > lineLength {
+					t.Errorf("line %q is longer than %d octets", line, lineLength)
+				}
+			}
+		})
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 15, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	got := string(Calendar("-//club//pc orders//EN", "Orders", []Event{{
+		UID:      "1@club",
+		Start:    start,
+		End:      start.Add(2 * time.Hour),
+		Summary:  "PC 3, row 1",
+		Location: "Main; hall",
+	}}))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Orders\r\n",
+		"BEGIN:VEVENT\r\nUID:1@club\r\n",
+		"DTSTART:20240101T120000Z\r\n",
+		"DTEND:20240101T140000Z\r\n",
+		`SUMMARY:PC 3\, row 1` + "\r\n",
+		`LOCATION:Main\; hall` + "\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("calendar does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "DESCRIPTION") {
+		t.Errorf("calendar contains empty description:\n%s", got)
+	}
+}
@@ -0,0 +1,71 @@
+package images
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"server/internal/lib/random"
+	"strings"
+)
+
+const nameLength = 24
+
+// extensions maps sniffed content types of accepted images to file extensions
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var ErrUnsupportedType = errors.New("unsupported image type")
+
+// Extension sniffs content type of the image by its first bytes and returns
+// file extension for it, the type declared by the client is not trusted
+func Extension(data []byte) (string, error) {
+	ext, ok := extensions[http.DetectContentType(data)]
+	if !ok {
+		return "", ErrUnsupportedType
+	}
+	return ext, nil
+}
+
+// Save writes the image to a new randomly named file with ext in dir
+// creating dir when needed. It returns name of the file
+func Save(dir string, data []byte, ext string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	code, err := random.Code(nameLength)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ToLower(code) + ext
+
+	file, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return "", err
+	}
+
+	return name, nil
+}
+
+// Remove deletes the file with name from dir, missing file is not an error
+func Remove(dir string, name string) error {
+	err := os.Remove(filepath.Join(dir, filepath.Base(name)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	OriginalVariant = "original"
+
+	// maxPixels limits decoded image size to protect from decompression bombs
+	maxPixels   = 50_000_000
+	jpegQuality = 82
+	webpExt     = ".webp"
+)
+
+// Variant is a copy of the original image resized to the width
+type Variant struct {
+	Name  string
+	Width int
+}
+
+// Variants are resized copies generated for every image, the original
+// is never upscaled so a variant can be as large as the original
+var Variants = []Variant{
+	{Name: "thumbnail", Width: 160},
+	{Name: "medium", Width: 640},
+}
+
+var ErrTooManyPixels = errors.New("image dimensions are too large")
+
+// Source is a variant of the image in its own format and in WebP
+type Source struct {
+	Width int    `json:"width"`
+	Src   string `json:"src"`
+	WebP  string `json:"webp"`
+}
+
+// Set is a srcset-style description of the image variants, srcset strings
+// are keyed by format, default one is the original format
+type Set struct {
+	Variants map[string]Source `json:"variants"`
+	Srcset   map[string]string `json:"srcset"`
+}
+
+// Generate writes resized variants and WebP versions of the original image
+// saved as name in dir next to it. It returns size of the original
+func Generate(dir string, name string, data []byte) (int, int, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, errors.Join(ErrUnsupportedType, err)
+	}
+	if config.Width*config.Height > maxPixels {
+		return 0, 0, ErrTooManyPixels
+	}
+
+	original, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, errors.Join(ErrUnsupportedType, err)
+	}
+	width, height := original.Bounds().Dx(), original.Bounds().Dy()
+
+	ext := filepath.Ext(name)
+	if ext != webpExt {
+		if err := writeFile(dir, variantName(name, OriginalVariant, webpExt), original, webpExt); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	for _, variant := range Variants {
+		resized := resize(original, variant.Width)
+		if err := writeFile(dir, variantName(name, variant.Name, ext), resized, ext); err != nil {
+			return 0, 0, err
+		}
+		if err := writeFile(dir, variantName(name, variant.Name, webpExt), resized, webpExt); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return width, height, nil
+}
+
+// Sources describes variants of the image with public path
+// and size of the original as Generate named them
+func Sources(imagePath string, width int, height int) Set {
+	ext := path.Ext(imagePath)
+	set := Set{
+		Variants: make(map[string]Source, len(Variants)+1),
+		Srcset:   make(map[string]string, 2),
+	}
+
+	var srcset, webpSrcset []string
+	for _, variant := range Variants {
+		source := Source{
+			Width: min(variant.Width, width),
+			Src:   variantName(imagePath, variant.Name, ext),
+			WebP:  variantName(imagePath, variant.Name, webpExt),
+		}
+		set.Variants[variant.Name] = source
+		srcset = append(srcset, fmt.Sprintf("%s %dw", source.Src, source.Width))
+		webpSrcset = append(webpSrcset, fmt.Sprintf("%s %dw", source.WebP, source.Width))
+	}
+
+	original := Source{
+		Width: width,
+		Src:   imagePath,
+		WebP:  imagePath,
+	}
+	if ext != webpExt {
+		original.WebP = variantName(imagePath, OriginalVariant, webpExt)
+	}
+	set.Variants[OriginalVariant] = original
+	srcset = append(srcset, fmt.Sprintf("%s %dw", original.Src, original.Width))
+	webpSrcset = append(webpSrcset, fmt.Sprintf("%s %dw", original.WebP, original.Width))
+
+	set.Srcset["default"] = strings.Join(srcset, ", ")
+	set.Srcset["webp"] = strings.Join(webpSrcset, ", ")
+
+	return set
+}
+
+// RemoveAll deletes the original image with name from dir and all its variants
+func RemoveAll(dir string, name string) error {
+	name = filepath.Base(name)
+	ext := filepath.Ext(name)
+
+	names := []string{name, variantName(name, OriginalVariant, webpExt)}
+	for _, variant := range Variants {
+		names = append(names, variantName(name, variant.Name, ext), variantName(name, variant.Name, webpExt))
+	}
+
+	var errs []error
+	for _, name := range names {
+		errs = append(errs, Remove(dir, name))
+	}
+	return errors.Join(errs...)
+}
+
+// variantName returns name of the variant file of the original name with ext
+func variantName(name string, variant string, ext string) string {
+	return strings.TrimSuffix(name, path.Ext(name)) + "_" + variant + ext
+}
+
+// resize scales the image down to width keeping its aspect ratio
+func resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func writeFile(dir string, name string, img image.Image, ext string) error {
+	file, err := os.Create(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+
+	if err := encode(file, img, ext); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+
+	return file.Close()
+}
+
+// encode writes the image in format by ext, formats without pure Go
+// lossy encoder are written as PNG
+func encode(w io.Writer, img image.Image, ext string) error {
+	switch ext {
+	case ".jpg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	case ".gif":
+		return gif.Encode(w, img, nil)
+	case webpExt:
+		return nativewebp.Encode(w, img, nil)
+	default:
+		return png.Encode(w, img)
+	}
+}
@@ -0,0 +1,220 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact amount in minor units (kopecks). It is stored as
+// DECIMAL with Scale fraction digits and is written to JSON as a decimal number
+type Money int64
+
+const (
+	Scale = 2
+	unit  = 100
+)
+
+var (
+	ErrInvalid   = errors.New("invalid money amount")
+	ErrPrecision = errors.New("money amount has too many fraction digits")
+	ErrOverflow  = errors.New("money amount overflows")
+)
+
+// Parse parses decimal string like "-12.3" exactly, fraction digits
+// beyond Scale are allowed only when they are zeros
+func Parse(s string) (Money, error) {
+	if s == "" {
+		return 0, ErrInvalid
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, ErrInvalid
+	}
+	if !digits(whole) || !digits(fraction) {
+		return 0, ErrInvalid
+	}
+
+	if len(fraction) > Scale {
+		if strings.Trim(fraction[Scale:], "0") != "" {
+			return 0, ErrPrecision
+		}
+		fraction = fraction[:Scale]
+	}
+	fraction += strings.Repeat("0", Scale-len(fraction))
+
+	value, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, ErrOverflow
+	}
+
+	if negative {
+		value = -value
+	}
+	return Money(value), nil
+}
+
+// FromMajor returns amount of whole major units (roubles)
+func FromMajor(major int64) Money {
+	return Money(major * unit)
+}
+
+// Major returns amount in major units, it is inexact and must not be
+// used in calculations
+func (m Money) Major() float64 {
+	return float64(m) / unit
+}
+
+// String returns amount as decimal string with exactly Scale fraction digits
+func (m Money) String() string {
+	value := int64(m)
+	sign := ""
+	if value < 0 {
+		sign = "-"
+	}
+
+	abs := new(big.Int).Abs(big.NewInt(value)).String()
+	if len(abs) <= Scale {
+		abs = strings.Repeat("0", Scale-len(abs)+1) + abs
+	}
+
+	return sign + abs[:len(abs)-Scale] + "." + abs[len(abs)-Scale:]
+}
+
+// MulDiv returns amount multiplied by num/den rounded half away from zero
+// to minor units. It is used for percents and time based costs, results
+// which do not fit in Money saturate to its bounds
+func (m Money) MulDiv(num, den int64) Money {
+	value := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(num))
+	divisor := big.NewInt(den)
+
+	quotient, remainder := new(big.Int).QuoRem(value, divisor, new(big.Int))
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.Cmp(new(big.Int).Abs(divisor)) >= 0 {
+		if value.Sign()*divisor.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		if quotient.Sign() < 0 {
+			return math.MinInt64
+		}
+		return math.MaxInt64
+	}
+
+	return Money(quotient.Int64())
+}
+
+// Percent returns percent of the amount rounded to minor units
+func (m Money) Percent(percent int64) Money {
+	return m.MulDiv(percent, 100)
+}
+
+// Mul returns amount multiplied by the count
+func (m Money) Mul(count int64) Money {
+	return m * Money(count)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts both JSON number and string holding a number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	value, err := parseJSONNumber(s)
+	if err != nil {
+		return fmt.Errorf("money: %w: %s", err, string(data))
+	}
+
+	*m = value
+	return nil
+}
+
+// Value writes the amount as decimal string, so the server converts it
+// to DECIMAL without passing through binary floating point
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan reads DECIMAL, NUMERIC and MONEY which driver returns as decimal
+// string bytes and FLOAT columns left from old schema. Integer columns are
+// rejected, they do not tell whether they hold major or minor units
+func (m *Money) Scan(src any) error {
+	var value Money
+	var err error
+
+	switch src := src.(type) {
+	case nil:
+		value = 0
+	case []byte:
+		value, err = Parse(string(src))
+	case string:
+		value, err = Parse(src)
+	case float64:
+		value, err = Parse(strconv.FormatFloat(src, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("%w: unsupported type %T", ErrInvalid, src)
+	}
+	if err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+
+	*m = value
+	return nil
+}
+
+// parseJSONNumber parses number which may be in exponent form like 1.5e2
+func parseJSONNumber(s string) (Money, error) {
+	if !strings.ContainsAny(s, "eE") {
+		return Parse(s)
+	}
+
+	rat, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, ErrInvalid
+	}
+
+	minor := rat.Mul(rat, new(big.Rat).SetInt64(unit))
+	if !minor.IsInt() {
+		return 0, ErrPrecision
+	}
+	if !minor.Num().IsInt64() {
+		return 0, ErrOverflow
+	}
+
+	return Money(minor.Num().Int64()), nil
+}
+
+func digits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
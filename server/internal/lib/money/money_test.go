@@ -0,0 +1,195 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want Money
+		err  error
+	}{
+		{name: "whole", in: "12", want: 1200},
+		{name: "one fraction digit", in: "12.3", want: 1230},
+		{name: "two fraction digits", in: "12.34", want: 1234},
+		{name: "trailing zeros", in: "12.3400", want: 1234},
+		{name: "no whole part", in: ".5", want: 50},
+		{name: "no fraction part", in: "7.", want: 700},
+		{name: "plus sign", in: "+1.01", want: 101},
+		{name: "negative", in: "-0.05", want: -5},
+		{name: "zero", in: "0", want: 0},
+		{name: "empty", in: "", err: ErrInvalid},
+		{name: "sign only", in: "-", err: ErrInvalid},
+		{name: "dot only", in: ".", err: ErrInvalid},
+		{name: "letters", in: "1a.00", err: ErrInvalid},
+		{name: "two dots", in: "1.2.3", err: ErrInvalid},
+		{name: "too precise", in: "1.001", err: ErrPrecision},
+		{name: "overflow", in: "92233720368547758.08", err: ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: 0, want: "0.00"},
+		{in: 5, want: "0.05"},
+		{in: 50, want: "0.50"},
+		{in: 1234, want: "12.34"},
+		{in: -5, want: "-0.05"},
+		{in: -1234, want: "-12.34"},
+		{in: math.MaxInt64, want: "92233720368547758.07"},
+		{in: math.MinInt64, want: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.in.String(); got != tt.want {
+				t.Fatalf("String() = %q, want %q", got, tt.want)
+			}
+			if tt.in == math.MinInt64 {
+				return
+			}
+
+			parsed, err := Parse(tt.want)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.want, err)
+			}
+			if parsed != tt.in {
+				t.Errorf("Parse(%q) = %d, want %d", tt.want, parsed, tt.in)
+			}
+		})
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       Money
+		num, den int64
+		want     Money
+	}{
+		{name: "exact", in: 1000, num: 1, den: 4, want: 250},
+		{name: "round down", in: 100, num: 1, den: 3, want: 33},
+		{name: "round up", in: 200, num: 1, den: 3, want: 67},
+		{name: "half up", in: 5, num: 1, den: 2, want: 3},
+		{name: "negative half away", in: -5, num: 1, den: 2, want: -3},
+		{name: "negative round", in: -200, num: 1, den: 3, want: -67},
+		{name: "negative denominator", in: 5, num: 1, den: -2, want: -3},
+		{name: "percent", in: 12345, num: 15, den: 100, want: 1852},
+		{name: "saturate up", in: math.MaxInt64, num: 2, den: 1, want: math.MaxInt64},
+		{name: "saturate down", in: math.MaxInt64, num: -2, den: 1, want: math.MinInt64},
+		{name: "large intermediate", in: math.MaxInt64, num: 3, den: 3, want: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.in.MulDiv(tt.num, tt.den); got != tt.want {
+				t.Errorf("%d.MulDiv(%d, %d) = %d, want %d", tt.in, tt.num, tt.den, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     any
+		want    Money
+		wantErr bool
+	}{
+		{name: "nil", src: nil, want: 0},
+		{name: "decimal bytes", src: []byte("12.34"), want: 1234},
+		{name: "money bytes", src: []byte("12.3400"), want: 1234},
+		{name: "negative bytes", src: []byte("-0.50"), want: -50},
+		{name: "string", src: "7.5", want: 750},
+		{name: "float", src: 12.34, want: 1234},
+		{name: "integer", src: int64(12), wantErr: true},
+		{name: "too precise", src: []byte("1.005"), wantErr: true},
+		{name: "unsupported", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Money(-1)
+			err := got.Scan(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan(%v) error = %v, want error %v", tt.src, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	value, err := Money(-1234).Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+	if value != "-12.34" {
+		t.Errorf("Value() = %v, want -12.34", value)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{name: "number", in: `12.34`, want: 1234},
+		{name: "string", in: `"12.34"`, want: 1234},
+		{name: "exponent", in: `1.5e2`, want: 15000},
+		{name: "small exponent", in: `5e-2`, want: 5},
+		{name: "negative", in: `-0.1`, want: -10},
+		{name: "too precise", in: `0.001`, wantErr: true},
+		{name: "too precise exponent", in: `1e-3`, wantErr: true},
+		{name: "not a number", in: `"abc"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Money
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, want error %v", tt.in, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("Unmarshal(%s) = %d, want %d", tt.in, got, tt.want)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal(%d) error = %v", got, err)
+			}
+			var back Money
+			if err := json.Unmarshal(data, &back); err != nil || back != got {
+				t.Errorf("round trip of %d through %s = %d, %v", got, data, back, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package mock
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"server/internal/config"
+	"server/internal/lib/money"
+	"server/internal/lib/payments"
+	"server/internal/lib/random"
+	"sync"
+	"time"
+)
+
+const (
+	Name            = "mock"
+	SignatureHeader = "X-Mock-Signature"
+
+	idLength      = 24
+	maxWebhookLen = 1 << 16
+)
+
+var checkoutPage = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Mock payment</title></head>
+<body>
+<h1>Mock payment</h1>
+<p>{{.Description}}</p>
+<p>Amount: {{.Amount}}</p>
+{{if .Status}}<p>Status: {{.Status}}</p>{{end}}
+<form method="post" action="{{.ID}}/pay"><button type="submit">Pay</button></form>
+<form method="post" action="{{.ID}}/decline"><button type="submit">Decline</button></form>
+</body>
+</html>
+`))
+
+type payment struct {
+	ID          string
+	PaymentID   int64
+	Amount      money.Money
+	Description string
+	ReturnURL   string
+	Status      string
+	Refunded    money.Money
+}
+
+type event struct {
+	EventID    string      `json:"event_id"`
+	PaymentID  int64       `json:"order_id"`
+	ExternalID string      `json:"payment_id"`
+	Status     string      `json:"status"`
+	Amount     money.Money `json:"amount"`
+}
+
+// Provider is local payment gateway for development. It keeps payments in
+// memory, serves checkout page in place of the bank one and delivers signed
+// callbacks to the webhook just like a real provider does
+type Provider struct {
+	cfg         *config.MockPaymentsConfig
+	webhookPath string
+	client      *http.Client
+
+	mu       sync.Mutex
+	payments map[string]*payment
+}
+
+func New(cfg *config.MockPaymentsConfig, webhookPath string) *Provider {
+	return &Provider{
+		cfg:         cfg,
+		webhookPath: webhookPath,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.Insecure},
+			},
+		},
+		payments: make(map[string]*payment),
+	}
+}
+
+func (p *Provider) Name() string {
+	return Name
+}
+
+func (p *Provider) CreatePayment(
+	_ context.Context,
+	request payments.Request,
+) (payments.Created, error) {
+	id, err := random.Code(idLength)
+	if err != nil {
+		return payments.Created{}, fmt.Errorf("failed to generate payment id: %w", err)
+	}
+
+	p.mu.Lock()
+	p.payments[id] = &payment{
+		ID:          id,
+		PaymentID:   request.PaymentID,
+		Amount:      request.Amount,
+		Description: request.Description,
+		ReturnURL:   request.ReturnURL,
+	}
+	p.mu.Unlock()
+
+	return payments.Created{
+		ExternalID:  id,
+		RedirectURL: p.cfg.BaseURL + p.cfg.UrlPath + "/" + id,
+	}, nil
+}
+
+func (p *Provider) Webhook(r *http.Request) (payments.Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookLen))
+	if err != nil {
+		return payments.Event{}, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+
+	signature, err := hex.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil || !hmac.Equal(signature, p.sign(body)) {
+		return payments.Event{}, payments.ErrInvalidSignature
+	}
+
+	var e event
+	if err := json.Unmarshal(body, &e); err != nil {
+		return payments.Event{}, fmt.Errorf("%w: %w", payments.ErrInvalidEvent, err)
+	}
+	if e.EventID == "" || e.PaymentID == 0 || e.ExternalID == "" ||
+		e.Status != payments.StatusSucceeded && e.Status != payments.StatusFailed {
+
+		return payments.Event{}, payments.ErrInvalidEvent
+	}
+
+	return payments.Event{
+		EventID:    e.EventID,
+		PaymentID:  e.PaymentID,
+		ExternalID: e.ExternalID,
+		Status:     e.Status,
+		Amount:     e.Amount,
+	}, nil
+}
+
+// Refund returns amount of succeeded payment, payments unknown to the
+// mock (created before restart) are refunded without checks
+func (p *Provider) Refund(
+	_ context.Context,
+	externalID string,
+	amount money.Money,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, ok := p.payments[externalID]
+	if !ok {
+		return nil
+	}
+	if pay.Status != payments.StatusSucceeded || pay.Refunded+amount > pay.Amount {
+		return fmt.Errorf("payment %s can not be refunded", externalID)
+	}
+	pay.Refunded += amount
+
+	return nil
+}
+
+// Handler serves checkout pages, it is mounted at UrlPath. Paying or
+// declining delivers callback to the webhook and redirects user back
+func (p *Provider) Handler() http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/{payment-id}", p.checkout)
+	r.Post("/{payment-id}/pay", p.complete(payments.StatusSucceeded))
+	r.Post("/{payment-id}/decline", p.complete(payments.StatusFailed))
+
+	return r
+}
+
+func (p *Provider) checkout(w http.ResponseWriter, r *http.Request) {
+	pay, ok := p.payment(chi.URLParam(r, "payment-id"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = checkoutPage.Execute(w, pay)
+}
+
+func (p *Provider) complete(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "payment-id")
+
+		p.mu.Lock()
+		pay, ok := p.payments[id]
+		if ok && pay.Status == "" {
+			pay.Status = status
+		}
+		var current payment
+		if ok {
+			current = *pay
+		}
+		p.mu.Unlock()
+
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		// callback is delivered again on every submit, the webhook
+		// has to ignore results it has already processed
+		if err := p.deliver(r.Context(), current); err != nil {
+			http.Error(w, "failed to deliver callback: "+err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		http.Redirect(w, r, returnURL(current), http.StatusSeeOther)
+	}
+}
+
+func (p *Provider) deliver(ctx context.Context, pay payment) error {
+	eventID, err := random.Code(idLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate event id: %w", err)
+	}
+
+	body, err := json.Marshal(event{
+		EventID:    eventID,
+		PaymentID:  pay.PaymentID,
+		ExternalID: pay.ID,
+		Status:     pay.Status,
+		Amount:     pay.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.BaseURL+p.webhookPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, hex.EncodeToString(p.sign(body)))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *Provider) payment(id string) (payment, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	pay, ok := p.payments[id]
+	if !ok {
+		return payment{}, false
+	}
+	return *pay, true
+}
+
+func (p *Provider) sign(body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(p.cfg.Secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func returnURL(pay payment) string {
+	target, err := url.Parse(pay.ReturnURL)
+	if err != nil {
+		return pay.ReturnURL
+	}
+
+	query := target.Query()
+	query.Set("status", pay.Status)
+	query.Set("amount", pay.Amount.String())
+	query.Set("external-id", pay.ID)
+	target.RawQuery = query.Encode()
+
+	return target.String()
+}
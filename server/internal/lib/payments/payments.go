@@ -0,0 +1,69 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"server/internal/lib/money"
+)
+
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidEvent     = errors.New("invalid webhook event")
+	ErrNotFound         = errors.New("payment not found")
+)
+
+// Request describes payment to create at the provider, PaymentID
+// is id of the payment on our side
+type Request struct {
+	PaymentID   int64
+	Amount      money.Money
+	Description string
+	ReturnURL   string
+}
+
+// Created is payment registered at the provider, user has to be
+// redirected to RedirectURL to pay it
+type Created struct {
+	ExternalID  string
+	RedirectURL string
+}
+
+// Event is verified payment result reported by the provider webhook,
+// EventID is unique for every delivery of the same result. PaymentID is
+// id from Request which the provider echoes back, so the payment is found
+// even when its external id was not saved
+type Event struct {
+	EventID    string
+	PaymentID  int64
+	ExternalID string
+	Status     string
+	Amount     money.Money
+}
+
+// Provider is payment gateway used to top up balances
+type Provider interface {
+	Name() string
+
+	CreatePayment(
+		ctx context.Context,
+		request Request,
+	) (created Created, err error)
+
+	// Webhook verifies signature of the provider callback and returns
+	// its event, ErrInvalidSignature is returned for forged requests
+	Webhook(
+		r *http.Request,
+	) (event Event, err error)
+
+	Refund(
+		ctx context.Context,
+		externalID string,
+		amount money.Money,
+	) (err error)
+}
@@ -0,0 +1,23 @@
+package random
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// Code returns cryptographically random string of given length
+// built from upper case letters and digits without similar looking symbols
+func Code(length int) (string, error) {
+	code := make([]byte, length)
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func Decode(r *http.Request, s interface{}) error {
@@ -87,9 +88,19 @@ func separateTag(tag string) (string, bool, error) {
 }
 
 func setField(field reflect.Value, fieldType reflect.StructField, value string) error {
+	if fieldType.Type == reflect.TypeOf(time.Time{}) {
+		return setTimeField(field, fieldType, value)
+	}
+
 	switch field.Kind() {
 	case reflect.String:
 		field.SetString(value)
+	case reflect.Bool:
+		res, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%w: failed parse field %s to bool", ErrConvertFailed, fieldType.Name)
+		}
+		field.SetBool(res)
 	case reflect.Int:
 		res, err := strconv.Atoi(value)
 		if err != nil {
@@ -137,3 +148,18 @@ func setField(field reflect.Value, fieldType reflect.StructField, value string)
 	}
 	return nil
 }
+
+// setTimeField parses RFC 3339 value, empty value leaves zero time
+func setTimeField(field reflect.Value, fieldType reflect.StructField, value string) error {
+	if value == "" || value == fmt.Sprintf("%v", time.Time{}) {
+		field.Set(reflect.ValueOf(time.Time{}))
+		return nil
+	}
+
+	res, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("%w: failed parse field %s to time", ErrConvertFailed, fieldType.Name)
+	}
+	field.Set(reflect.ValueOf(res))
+	return nil
+}
@@ -12,6 +12,8 @@ type DishImage struct {
 	DishID      int64  `gorm:"column:dish_id;not null" json:"dish_id"`
 	IsMain      bool   `gorm:"column:is_main;not null;default:0" json:"is_main"`
 	Path        string `gorm:"column:path" json:"path"`
+	Width       int    `gorm:"column:width;not null;default:0" json:"width"`
+	Height      int    `gorm:"column:height;not null;default:0" json:"height"`
 	Dish        Dish   `json:"dish"`
 }
 
@@ -6,6 +6,8 @@ package models
 
 import (
 	"time"
+
+	"server/internal/lib/money"
 )
 
 const TableNameDishOrder = "dish_orders"
@@ -15,10 +17,12 @@ type DishOrder struct {
 	DishOrderID       int64           `gorm:"column:dish_order_id;primaryKey" json:"dish_order_id"`
 	DishOrderStatusID int64           `gorm:"column:dish_order_status_id;not null" json:"dish_order_status_id"`
 	UserID            int64           `gorm:"column:user_id;not null" json:"user_id"`
-	Cost              float32         `gorm:"column:cost;not null" json:"cost"`
+	PcOrderID         int64           `gorm:"column:pc_order_id" json:"pc_order_id"`
+	Cost              money.Money     `gorm:"column:cost;not null" json:"cost"`
 	OrderDate         time.Time       `gorm:"column:order_date;not null;default:getdate()" json:"order_date"`
 	DishOrderStatus   DishOrderStatus `json:"dish_order_status"`
 	User              User            `json:"user"`
+	PcOrder           PcOrder         `json:"pc_order"`
 	DishOrderList     []DishOrderList `json:"dish_order_list"`
 }
 
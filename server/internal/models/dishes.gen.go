@@ -4,6 +4,10 @@
 
 package models
 
+import (
+	"server/internal/lib/money"
+)
+
 const TableNameDish = "dishes"
 
 // Dish mapped from table <dishes>
@@ -12,7 +16,7 @@ type Dish struct {
 	DishStatusID  int64           `gorm:"column:dish_status_id;not null;default:1" json:"dish_status_id"`
 	Name          string          `gorm:"column:name;not null" json:"name"`
 	Calories      int16           `gorm:"column:calories;not null" json:"calories"`
-	Cost          float32         `gorm:"column:cost;not null" json:"cost"`
+	Cost          money.Money     `gorm:"column:cost;not null" json:"cost"`
 	Description   string          `gorm:"column:description" json:"description"`
 	DishStatus    DishStatus      `json:"dish_status"`
 	DishImages    []DishImage     `json:"dish_images"`
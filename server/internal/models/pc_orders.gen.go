@@ -6,6 +6,8 @@ package models
 
 import (
 	"time"
+
+	"server/internal/lib/money"
 )
 
 const TableNamePcOrder = "pc_orders"
@@ -17,7 +19,7 @@ type PcOrder struct {
 	PcID            int64         `gorm:"column:pc_id;not null" json:"pc_id"`
 	PcOrderStatusID int64         `gorm:"column:pc_order_status_id;not null" json:"pc_order_status_id"`
 	Code            string        `gorm:"column:code;not null" json:"code"`
-	Cost            float32       `gorm:"column:cost;not null" json:"cost"`
+	Cost            money.Money   `gorm:"column:cost;not null" json:"cost"`
 	StartTime       time.Time     `gorm:"column:start_time;not null" json:"start_time"`
 	Duration        int16         `gorm:"column:duration;not null" json:"duration"`
 	ActualEndTime   time.Time     `gorm:"column:actual_end_time;not null" json:"actual_end_time"`
@@ -12,6 +12,9 @@ type PcTypeImage struct {
 	PcTypeID      int64  `gorm:"column:pc_type_id;not null" json:"pc_type_id"`
 	IsMain        bool   `gorm:"column:is_main;not null;default:0" json:"is_main"`
 	Path          string `gorm:"column:path;not null" json:"path"`
+	Position      int16  `gorm:"column:position;not null;default:0" json:"position"`
+	Width         int    `gorm:"column:width;not null;default:0" json:"width"`
+	Height        int    `gorm:"column:height;not null;default:0" json:"height"`
 	PcType        PcType `json:"pc_type"`
 }
 
@@ -4,6 +4,10 @@
 
 package models
 
+import (
+	"server/internal/lib/money"
+)
+
 const TableNameUser = "users"
 
 // User mapped from table <users>
@@ -13,7 +17,7 @@ type User struct {
 	Email               string      `gorm:"column:email;not null" json:"email"`
 	Password            []uint8     `gorm:"column:password;not null" json:"password"`
 	RefreshTokenVersion int64       `gorm:"column:refresh_token_version;not null" json:"refresh_token_version"`
-	Balance             float32     `gorm:"column:balance;not null;default:0" json:"balance"`
+	Balance             money.Money `gorm:"column:balance;not null;default:0" json:"balance"`
 	UserRole            UserRole    `json:"user_role"`
 	PcOrders            []PcOrder   `json:"pc_orders"`
 	DishOrders          []DishOrder `json:"dish_orders"`
@@ -0,0 +1,61 @@
+package balance
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+)
+
+// TopUpBalance credits amount paid by the user at the club desk
+func (s *Service) TopUpBalance(
+	ctx context.Context,
+	uid int64,
+	adminID int64,
+	amount money.Money,
+	comment string,
+) (models.BalanceTransaction, error) {
+	const op = "services.pcClub.balance.TopUpBalance"
+
+	transaction, err := s.owner.TopUpBalance(ctx, uid, adminID, amount, comment)
+	if err != nil {
+		return models.BalanceTransaction{}, errors2.WithMessage(HandleStorageError(err), op, "failed to top up balance in mssql")
+	}
+
+	return transaction, nil
+}
+
+// AdjustBalance corrects the user balance by signed amount
+func (s *Service) AdjustBalance(
+	ctx context.Context,
+	uid int64,
+	adminID int64,
+	amount money.Money,
+	comment string,
+) (models.BalanceTransaction, error) {
+	const op = "services.pcClub.balance.AdjustBalance"
+
+	transaction, err := s.owner.AdjustBalance(ctx, uid, adminID, amount, comment)
+	if err != nil {
+		return models.BalanceTransaction{}, errors2.WithMessage(HandleStorageError(err), op, "failed to adjust balance in mssql")
+	}
+
+	return transaction, nil
+}
+
+// ReconcileBalance records the difference between the stored balance
+// and the ledger as an adjustment, so the ledger matches the balance again
+func (s *Service) ReconcileBalance(
+	ctx context.Context,
+	uid int64,
+	adminID int64,
+) (models.BalanceTransaction, error) {
+	const op = "services.pcClub.balance.ReconcileBalance"
+
+	transaction, err := s.owner.ReconcileBalance(ctx, uid, adminID)
+	if err != nil {
+		return models.BalanceTransaction{}, errors2.WithMessage(HandleStorageError(err), op, "failed to reconcile balance in mssql")
+	}
+
+	return transaction, nil
+}
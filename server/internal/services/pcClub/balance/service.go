@@ -0,0 +1,62 @@
+package balance
+
+import (
+	"context"
+	"server/internal/lib/money"
+	"server/internal/models"
+)
+
+type provider interface {
+	User(
+		ctx context.Context,
+		uid int64,
+	) (user models.User, err error)
+
+	BalanceTransactions(
+		ctx context.Context,
+		uid int64,
+		limit int,
+		offset int,
+	) (transactions []models.BalanceTransaction, err error)
+
+	LedgerBalance(
+		ctx context.Context,
+		uid int64,
+	) (balance money.Money, err error)
+}
+
+type owner interface {
+	TopUpBalance(
+		ctx context.Context,
+		uid int64,
+		actorID int64,
+		amount money.Money,
+		comment string,
+	) (transaction models.BalanceTransaction, err error)
+
+	AdjustBalance(
+		ctx context.Context,
+		uid int64,
+		adminID int64,
+		amount money.Money,
+		comment string,
+	) (transaction models.BalanceTransaction, err error)
+
+	ReconcileBalance(
+		ctx context.Context,
+		uid int64,
+		adminID int64,
+	) (transaction models.BalanceTransaction, err error)
+}
+
+type Service struct {
+	provider provider
+	owner    owner
+}
+
+func New(provider provider, owner owner) *Service {
+	return &Service{
+		provider: provider,
+		owner:    owner,
+	}
+}
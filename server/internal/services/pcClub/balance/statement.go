@@ -0,0 +1,51 @@
+package balance
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+)
+
+type Statement struct {
+	Balance       money.Money                 `json:"balance"`
+	LedgerBalance money.Money                 `json:"ledger_balance"`
+	Reconciled    bool                        `json:"reconciled"`
+	Transactions  []models.BalanceTransaction `json:"transactions"`
+}
+
+// Statement returns the user balance with page of its ledger, newest first.
+// Reconciled is false when the stored balance differs from the ledger sum
+func (s *Service) Statement(
+	ctx context.Context,
+	uid int64,
+	limit int,
+	offset int,
+) (Statement, error) {
+	const op = "services.pcClub.balance.Statement"
+
+	user, err := s.provider.User(ctx, uid)
+	if err != nil {
+		return Statement{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get user from mssql")
+	}
+
+	ledger, err := s.provider.LedgerBalance(ctx, uid)
+	if err != nil {
+		return Statement{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get ledger balance from mssql")
+	}
+
+	transactions, err := s.provider.BalanceTransactions(ctx, uid, limit, offset)
+	if err != nil {
+		return Statement{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get balance transactions from mssql")
+	}
+	if transactions == nil {
+		transactions = []models.BalanceTransaction{}
+	}
+
+	return Statement{
+		Balance:       user.Balance,
+		LedgerBalance: ledger,
+		Reconciled:    user.Balance == ledger,
+		Transactions:  transactions,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/ical"
+	"time"
+)
+
+// Feed renders upcoming pc orders of the token owner as iCalendar
+func (s *Service) Feed(
+	ctx context.Context,
+	token string,
+) ([]byte, error) {
+	const op = "services.pcClub.calendar.Feed"
+
+	uid, err := s.provider.CalendarTokenUser(ctx, token)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get calendar token user from mssql")
+	}
+
+	orders, err := s.provider.UpcomingPcOrders(ctx, uid, time.Now())
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get upcoming pc orders from mssql")
+	}
+
+	events := make([]ical.Event, 0, len(orders))
+	for _, order := range orders {
+		room := order.Pc.PcRoom
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("pc-order-%d@pcclub", order.PcOrderID),
+			Start:       order.StartTime,
+			End:         order.ActualEndTime,
+			Summary:     fmt.Sprintf("pcClub: %s, row %d, place %d", room.Name, order.Pc.Row, order.Pc.Place),
+			Location:    fmt.Sprintf("%s, row %d, place %d", room.Name, order.Pc.Row, order.Pc.Place),
+			Description: "Access code: " + order.Code,
+		})
+	}
+
+	return ical.Calendar(prodID, name, events), nil
+}
@@ -0,0 +1,46 @@
+package calendar
+
+import (
+	"context"
+	"server/internal/models"
+	"time"
+)
+
+type provider interface {
+	CalendarTokenUser(
+		ctx context.Context,
+		token string,
+	) (uid int64, err error)
+
+	UpcomingPcOrders(
+		ctx context.Context,
+		uid int64,
+		after time.Time,
+	) (orders []models.PcOrder, err error)
+}
+
+type owner interface {
+	SaveCalendarToken(
+		ctx context.Context,
+		uid int64,
+		token string,
+	) (err error)
+}
+
+type Service struct {
+	provider provider
+	owner    owner
+}
+
+const (
+	tokenLength = 32
+	prodID      = "-//pcClub//Bookings//EN"
+	name        = "pcClub bookings"
+)
+
+func New(provider provider, owner owner) *Service {
+	return &Service{
+		provider: provider,
+		owner:    owner,
+	}
+}
@@ -0,0 +1,27 @@
+package calendar
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/random"
+)
+
+// CalendarToken issues new secret feed token of the user,
+// the previous token stops working
+func (s *Service) CalendarToken(
+	ctx context.Context,
+	uid int64,
+) (string, error) {
+	const op = "services.pcClub.calendar.CalendarToken"
+
+	token, err := random.Code(tokenLength)
+	if err != nil {
+		return "", errors2.WithMessage(err, op, "failed to generate calendar token")
+	}
+
+	if err := s.owner.SaveCalendarToken(ctx, uid, token); err != nil {
+		return "", errors2.WithMessage(HandleStorageError(err), op, "failed to save calendar token in mssql")
+	}
+
+	return token, nil
+}
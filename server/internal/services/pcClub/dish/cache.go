@@ -0,0 +1,33 @@
+package dish
+
+import (
+	"context"
+	"fmt"
+	errors2 "server/internal/lib/errors"
+)
+
+const listCachePattern = "dishes:*"
+
+// CacheKey returns redis key of the cached dish
+func CacheKey(dishID int64) string {
+	return fmt.Sprintf("dish:%d", dishID)
+}
+
+// ListCacheKey returns redis key of the cached page of dishes
+func ListCacheKey(limit int, offset int) string {
+	return fmt.Sprintf("dishes:%d-%d", limit, offset)
+}
+
+// Forget drops cached dishes and all cached dish lists,
+// it is called when dishes change outside of this service
+func (s *Service) Forget(ctx context.Context, dishIDs ...int64) error {
+	for _, dishID := range dishIDs {
+		if err := s.redisOwner.Delete(ctx, CacheKey(dishID)); err != nil {
+			return errors2.WithMessage(err, "failed to delete dish from redis")
+		}
+	}
+	if err := s.redisOwner.DeleteByPattern(ctx, listCachePattern); err != nil {
+		return errors2.WithMessage(err, "failed to delete dishes from redis")
+	}
+	return nil
+}
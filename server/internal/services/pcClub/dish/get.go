@@ -3,7 +3,6 @@ package dish
 import (
 	"context"
 	"errors"
-	"fmt"
 	errors2 "server/internal/lib/errors"
 	"server/internal/models"
 	"server/internal/storage/redis"
@@ -19,7 +18,7 @@ func (s *Service) Dishes(
 	var dishes []models.Dish
 	err := s.redisProvider.Value(
 		ctx,
-		fmt.Sprintf("dishes:%d-%d", limit, offset),
+		ListCacheKey(limit, offset),
 		&dishes,
 	)
 	if err == nil {
@@ -36,7 +35,7 @@ func (s *Service) Dishes(
 
 	if err = s.redisOwner.Set(
 		ctx,
-		fmt.Sprintf("dishes:%d-%d", limit, offset),
+		ListCacheKey(limit, offset),
 		dishes,
 	); err != nil {
 		return nil, errors2.WithMessage(err, op, "failed to write dishes in redis")
@@ -54,7 +53,7 @@ func (s *Service) Dish(
 	var dish models.Dish
 	err := s.redisProvider.Value(
 		ctx,
-		fmt.Sprintf("dish:%d", dishID),
+		CacheKey(dishID),
 		&dish,
 	)
 	if err == nil {
@@ -71,7 +70,7 @@ func (s *Service) Dish(
 
 	if err = s.redisOwner.Set(
 		ctx,
-		fmt.Sprintf("dish:%d", dishID),
+		CacheKey(dishID),
 		dish,
 	); err != nil {
 		return models.Dish{}, errors2.WithMessage(err, op, "failed to write dish in redis")
@@ -50,6 +50,16 @@ type redisOwner interface {
 		key string,
 		value interface{},
 	) (err error)
+
+	Delete(
+		ctx context.Context,
+		key string,
+	) (err error)
+
+	DeleteByPattern(
+		ctx context.Context,
+		pattern string,
+	) (err error)
 }
 
 type Service struct {
@@ -0,0 +1,30 @@
+package dishImage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/images"
+)
+
+// DeleteDishImage deletes the image and its files
+func (s *Service) DeleteDishImage(
+	ctx context.Context,
+	imageID int64,
+) error {
+	const op = "services.pcClub.dishImage.DeleteDishImage"
+
+	dishImage, err := s.owner.DeleteDishImage(ctx, imageID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete dish image from mssql")
+	}
+
+	if err := s.dishCache.Forget(ctx, dishImage.DishID); err != nil {
+		return errors2.WithMessage(err, op)
+	}
+
+	if err := images.RemoveAll(s.cfg.Path, dishImage.Path); err != nil {
+		return errors2.WithMessage(err, op, "failed to remove image files")
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package dishImage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) DishImages(
+	ctx context.Context,
+	dishID int64,
+) ([]models.DishImage, error) {
+	const op = "services.pcClub.dishImage.DishImages"
+
+	dishImages, err := s.provider.DishImages(ctx, dishID)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish images from mssql")
+	}
+
+	return dishImages, nil
+}
@@ -0,0 +1,94 @@
+package dishImage
+
+import (
+	"context"
+	"errors"
+	"path"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/images"
+	"server/internal/models"
+)
+
+// SaveDishImage stores the image file with its resized and WebP variants under
+// the configured path and saves it for the dish, the image type is sniffed from its content.
+// Path of the saved image is its public url
+func (s *Service) SaveDishImage(
+	ctx context.Context,
+	dishID int64,
+	data []byte,
+	isMain bool,
+) (models.DishImage, error) {
+	const op = "services.pcClub.dishImage.SaveDishImage"
+
+	if int64(len(data)) > s.cfg.MaxSize {
+		return models.DishImage{}, errors2.WithMessage(ErrTooLarge, op)
+	}
+
+	ext, err := images.Extension(data)
+	if err != nil {
+		if errors.Is(err, images.ErrUnsupportedType) {
+			return models.DishImage{}, errors2.WithMessage(ErrUnsupportedType, op)
+		}
+		return models.DishImage{}, errors2.WithMessage(err, op, "failed to sniff image type")
+	}
+
+	name, err := images.Save(s.cfg.Path, data, ext)
+	if err != nil {
+		return models.DishImage{}, errors2.WithMessage(err, op, "failed to write image file")
+	}
+
+	width, height, err := images.Generate(s.cfg.Path, name, data)
+	if err != nil {
+		_ = images.RemoveAll(s.cfg.Path, name)
+		return models.DishImage{}, errors2.WithMessage(variantsError(err), op, "failed to generate image variants")
+	}
+
+	dishImage := models.DishImage{
+		DishID: dishID,
+		IsMain: isMain,
+		Path:   path.Join(s.cfg.UrlPath, name),
+		Width:  width,
+		Height: height,
+	}
+	if _, err := s.owner.SaveDishImage(ctx, &dishImage); err != nil {
+		_ = images.RemoveAll(s.cfg.Path, name)
+		return models.DishImage{}, errors2.WithMessage(HandleStorageError(err), op, "failed to save dish image in mssql")
+	}
+
+	if err := s.dishCache.Forget(ctx, dishID); err != nil {
+		return models.DishImage{}, errors2.WithMessage(err, op)
+	}
+
+	return dishImage, nil
+}
+
+// SetMainDishImage makes the image main for its dish
+func (s *Service) SetMainDishImage(
+	ctx context.Context,
+	imageID int64,
+) error {
+	const op = "services.pcClub.dishImage.SetMainDishImage"
+
+	dishImage, err := s.owner.SetMainDishImage(ctx, imageID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to set main dish image in mssql")
+	}
+
+	if err := s.dishCache.Forget(ctx, dishImage.DishID); err != nil {
+		return errors2.WithMessage(err, op)
+	}
+
+	return nil
+}
+
+// variantsError maps errors of image decoding to service errors
+func variantsError(err error) error {
+	switch {
+	case errors.Is(err, images.ErrUnsupportedType):
+		return ErrUnsupportedType
+	case errors.Is(err, images.ErrTooManyPixels):
+		return ErrTooLarge
+	default:
+		return err
+	}
+}
@@ -0,0 +1,59 @@
+package dishImage
+
+import (
+	"context"
+	"server/internal/config"
+	"server/internal/models"
+)
+
+type provider interface {
+	DishImages(
+		ctx context.Context,
+		dishID int64,
+	) (images []models.DishImage, err error)
+}
+
+type owner interface {
+	SaveDishImage(
+		ctx context.Context,
+		dishImage *models.DishImage,
+	) (id int64, err error)
+
+	SetMainDishImage(
+		ctx context.Context,
+		imageID int64,
+	) (dishImage models.DishImage, err error)
+
+	DeleteDishImage(
+		ctx context.Context,
+		imageID int64,
+	) (dishImage models.DishImage, err error)
+}
+
+type dishCache interface {
+	Forget(
+		ctx context.Context,
+		dishIDs ...int64,
+	) (err error)
+}
+
+type Service struct {
+	cfg       *config.DishesImagesConfig
+	provider  provider
+	owner     owner
+	dishCache dishCache
+}
+
+func New(
+	cfg *config.DishesImagesConfig,
+	provider provider,
+	owner owner,
+	dishCache dishCache,
+) *Service {
+	return &Service{
+		cfg:       cfg,
+		provider:  provider,
+		owner:     owner,
+		dishCache: dishCache,
+	}
+}
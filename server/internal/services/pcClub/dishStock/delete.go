@@ -0,0 +1,22 @@
+package dishStock
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+)
+
+// DeleteDishStock stops tracking stock of the dish
+func (s *Service) DeleteDishStock(
+	ctx context.Context,
+	dishID int64,
+) error {
+	const op = "services.pcClub.dishStock.DeleteDishStock"
+
+	if err := s.owner.DeleteDishStock(ctx, dishID); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete dish stock from mssql")
+	}
+
+	s.forgetDishes(ctx, op, dishID)
+
+	return nil
+}
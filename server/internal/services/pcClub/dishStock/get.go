@@ -0,0 +1,20 @@
+package dishStock
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) DishStocks(
+	ctx context.Context,
+) ([]models.DishStock, error) {
+	const op = "services.pcClub.dishStock.DishStocks"
+
+	stocks, err := s.provider.DishStocks(ctx)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish stocks from mssql")
+	}
+
+	return stocks, nil
+}
@@ -0,0 +1,51 @@
+package dishStock
+
+import (
+	"context"
+	"log/slog"
+	"server/internal/lib/api/logger/sl"
+	errors2 "server/internal/lib/errors"
+)
+
+// SaveDishStock sets stock of the dish starting to track it when needed,
+// the dish goes out of stock when count is zero
+func (s *Service) SaveDishStock(
+	ctx context.Context,
+	dishID int64,
+	count int,
+) error {
+	const op = "services.pcClub.dishStock.SaveDishStock"
+
+	if err := s.owner.SaveDishStock(ctx, dishID, count); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to save dish stock in mssql")
+	}
+
+	s.forgetDishes(ctx, op, dishID)
+
+	return nil
+}
+
+// RestockDish adds count to the tracked stock of the dish
+func (s *Service) RestockDish(
+	ctx context.Context,
+	dishID int64,
+	count int,
+) error {
+	const op = "services.pcClub.dishStock.RestockDish"
+
+	if err := s.owner.RestockDish(ctx, dishID, count); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to restock dish in mssql")
+	}
+
+	s.forgetDishes(ctx, op, dishID)
+
+	return nil
+}
+
+// forgetDishes drops cached dishes after stock change is committed, failure
+// is only logged as the change has already been made and cache entries expire
+func (s *Service) forgetDishes(ctx context.Context, op string, dishIDs ...int64) {
+	if err := s.dishCache.Forget(ctx, dishIDs...); err != nil {
+		s.log.Warn("failed to forget cached dishes", slog.String("operation", op), sl.Err(err))
+	}
+}
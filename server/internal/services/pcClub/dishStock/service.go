@@ -0,0 +1,55 @@
+package dishStock
+
+import (
+	"context"
+	"log/slog"
+	"server/internal/models"
+)
+
+type provider interface {
+	DishStocks(
+		ctx context.Context,
+	) (stocks []models.DishStock, err error)
+}
+
+type owner interface {
+	SaveDishStock(
+		ctx context.Context,
+		dishID int64,
+		count int,
+	) (err error)
+
+	RestockDish(
+		ctx context.Context,
+		dishID int64,
+		count int,
+	) (err error)
+
+	DeleteDishStock(
+		ctx context.Context,
+		dishID int64,
+	) (err error)
+}
+
+type dishCache interface {
+	Forget(
+		ctx context.Context,
+		dishIDs ...int64,
+	) (err error)
+}
+
+type Service struct {
+	log       *slog.Logger
+	provider  provider
+	owner     owner
+	dishCache dishCache
+}
+
+func New(log *slog.Logger, provider provider, owner owner, dishCache dishCache) *Service {
+	return &Service{
+		log:       log,
+		provider:  provider,
+		owner:     owner,
+		dishCache: dishCache,
+	}
+}
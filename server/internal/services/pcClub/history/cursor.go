@@ -0,0 +1,51 @@
+package history
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"time"
+)
+
+// cursor is the key of the last returned item. Items are ordered by
+// time, kind and id descending, so dish orders go before pc orders
+// of the same time
+type cursor struct {
+	time time.Time
+	kind int
+	id   int64
+}
+
+func (c cursor) String() string {
+	raw := fmt.Sprintf("%d:%d:%d", c.time.UnixNano(), c.kind, c.id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func parseCursor(value string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor{}, err
+	}
+
+	var nanos int64
+	var c cursor
+	if _, err := fmt.Sscanf(string(raw), "%d:%d:%d", &nanos, &c.kind, &c.id); err != nil {
+		return cursor{}, err
+	}
+	c.time = time.Unix(0, nanos)
+
+	return c, nil
+}
+
+// bound returns key in terms of (time, id) of the kind items
+// which follow the cursor
+func (c cursor) bound(kind int) (time.Time, int64) {
+	switch {
+	case kind < c.kind:
+		return c.time, math.MaxInt64
+	case kind == c.kind:
+		return c.time, c.id
+	default:
+		return c.time, 0
+	}
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+	"time"
+)
+
+const (
+	PcOrderKind   = "pc"
+	DishOrderKind = "dish"
+)
+
+const (
+	pcOrderKind = iota
+	dishOrderKind
+)
+
+type Item struct {
+	Kind      string            `json:"kind"`
+	Time      time.Time         `json:"time"`
+	PcOrder   *models.PcOrder   `json:"pc_order,omitempty"`
+	DishOrder *models.DishOrder `json:"dish_order,omitempty"`
+}
+
+type Page struct {
+	Items      []Item `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// History returns users pc and dish orders merged into one list from
+// the newest to the oldest. Pc orders are placed by start time and dish
+// orders by order date, the cursor of the previous page continues it
+func (s *Service) History(
+	ctx context.Context,
+	uid int64,
+	status string,
+	from time.Time,
+	to time.Time,
+	after string,
+	limit int,
+) (Page, error) {
+	const op = "services.pcClub.history.History"
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	var last cursor
+	if after != "" {
+		var err error
+		if last, err = parseCursor(after); err != nil {
+			return Page{}, errors2.WithMessage(ErrInvalidCursor, op, err.Error())
+		}
+	}
+
+	pcBefore, pcBeforeID := last.bound(pcOrderKind)
+	pcOrders, err := s.provider.PcOrderHistory(ctx, uid, status, from, to, pcBefore, pcBeforeID, limit)
+	if err != nil {
+		return Page{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order history from mssql")
+	}
+
+	dishBefore, dishBeforeID := last.bound(dishOrderKind)
+	dishOrders, err := s.provider.DishOrderHistory(ctx, uid, status, from, to, dishBefore, dishBeforeID, limit)
+	if err != nil {
+		return Page{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish order history from mssql")
+	}
+
+	page := Page{Items: make([]Item, 0, limit)}
+	var lastKey cursor
+	for len(page.Items) < limit && (len(pcOrders) != 0 || len(dishOrders) != 0) {
+		takeDish := len(pcOrders) == 0 ||
+			len(dishOrders) != 0 && !dishOrders[0].OrderDate.Before(pcOrders[0].StartTime)
+
+		if takeDish {
+			order := dishOrders[0]
+			dishOrders = dishOrders[1:]
+			page.Items = append(page.Items, Item{Kind: DishOrderKind, Time: order.OrderDate, DishOrder: &order})
+			lastKey = cursor{time: order.OrderDate, kind: dishOrderKind, id: order.DishOrderID}
+		} else {
+			order := pcOrders[0]
+			pcOrders = pcOrders[1:]
+			page.Items = append(page.Items, Item{Kind: PcOrderKind, Time: order.StartTime, PcOrder: &order})
+			lastKey = cursor{time: order.StartTime, kind: pcOrderKind, id: order.PcOrderID}
+		}
+	}
+
+	if len(page.Items) == limit {
+		page.NextCursor = lastKey.String()
+	}
+
+	return page, nil
+}
@@ -0,0 +1,43 @@
+package history
+
+import (
+	"context"
+	"server/internal/models"
+	"time"
+)
+
+type provider interface {
+	PcOrderHistory(
+		ctx context.Context,
+		uid int64,
+		status string,
+		from time.Time,
+		to time.Time,
+		before time.Time,
+		beforeID int64,
+		limit int,
+	) (orders []models.PcOrder, err error)
+
+	DishOrderHistory(
+		ctx context.Context,
+		uid int64,
+		status string,
+		from time.Time,
+		to time.Time,
+		before time.Time,
+		beforeID int64,
+		limit int,
+	) (orders []models.DishOrder, err error)
+}
+
+type Service struct {
+	provider provider
+}
+
+const defaultLimit = 20
+
+func New(provider provider) *Service {
+	return &Service{
+		provider: provider,
+	}
+}
@@ -0,0 +1,23 @@
+package hourPackage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+)
+
+// BuyHourPackage pays the package from users balance and puts
+// its minutes to users wallet, it returns id of the wallet entry
+func (s *Service) BuyHourPackage(
+	ctx context.Context,
+	uid int64,
+	packageID int64,
+) (int64, error) {
+	const op = "services.pcClub.hourPackage.BuyHourPackage"
+
+	id, err := s.owner.BuyHourPackage(ctx, uid, packageID)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to buy hour package in mssql")
+	}
+
+	return id, nil
+}
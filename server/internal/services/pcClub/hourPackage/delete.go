@@ -0,0 +1,19 @@
+package hourPackage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+)
+
+func (s *Service) DeleteHourPackage(
+	ctx context.Context,
+	packageID int64,
+) error {
+	const op = "services.pcClub.hourPackage.DeleteHourPackage"
+
+	if err := s.owner.DeleteHourPackage(ctx, packageID); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete hour package from mssql")
+	}
+
+	return nil
+}
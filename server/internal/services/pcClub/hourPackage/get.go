@@ -0,0 +1,36 @@
+package hourPackage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) HourPackages(
+	ctx context.Context,
+	typeID int64,
+) ([]models.HourPackage, error) {
+	const op = "services.pcClub.hourPackage.HourPackages"
+
+	packages, err := s.provider.HourPackages(ctx, typeID)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get hour packages from mssql")
+	}
+
+	return packages, nil
+}
+
+// UserHourPackages returns users wallet, packages with minutes left
+func (s *Service) UserHourPackages(
+	ctx context.Context,
+	uid int64,
+) ([]models.UserHourPackage, error) {
+	const op = "services.pcClub.hourPackage.UserHourPackages"
+
+	packages, err := s.provider.UserHourPackages(ctx, uid)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get user hour packages from mssql")
+	}
+
+	return packages, nil
+}
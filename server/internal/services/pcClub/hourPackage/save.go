@@ -0,0 +1,21 @@
+package hourPackage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) SaveHourPackage(
+	ctx context.Context,
+	hourPackage *models.HourPackage,
+) (int64, error) {
+	const op = "services.pcClub.hourPackage.SaveHourPackage"
+
+	id, err := s.owner.SaveHourPackage(ctx, hourPackage)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to save hour package in mssql")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,54 @@
+package hourPackage
+
+import (
+	"context"
+	"server/internal/models"
+)
+
+type provider interface {
+	HourPackages(
+		ctx context.Context,
+		typeID int64,
+	) (packages []models.HourPackage, err error)
+
+	UserHourPackages(
+		ctx context.Context,
+		uid int64,
+	) (packages []models.UserHourPackage, err error)
+}
+
+type owner interface {
+	SaveHourPackage(
+		ctx context.Context,
+		hourPackage *models.HourPackage,
+	) (id int64, err error)
+
+	UpdateHourPackage(
+		ctx context.Context,
+		packageID int64,
+		hourPackage *models.HourPackage,
+	) (err error)
+
+	DeleteHourPackage(
+		ctx context.Context,
+		packageID int64,
+	) (err error)
+
+	BuyHourPackage(
+		ctx context.Context,
+		uid int64,
+		packageID int64,
+	) (id int64, err error)
+}
+
+type Service struct {
+	provider provider
+	owner    owner
+}
+
+func New(provider provider, owner owner) *Service {
+	return &Service{
+		provider: provider,
+		owner:    owner,
+	}
+}
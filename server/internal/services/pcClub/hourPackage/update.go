@@ -0,0 +1,21 @@
+package hourPackage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) UpdateHourPackage(
+	ctx context.Context,
+	packageID int64,
+	hourPackage *models.HourPackage,
+) error {
+	const op = "services.pcClub.hourPackage.UpdateHourPackage"
+
+	if err := s.owner.UpdateHourPackage(ctx, packageID, hourPackage); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to update hour package in mssql")
+	}
+
+	return nil
+}
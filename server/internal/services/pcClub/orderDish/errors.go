@@ -0,0 +1,116 @@
+package orderDish
+
+import (
+	"errors"
+	errors2 "server/internal/lib/errors"
+	"server/internal/storage/mssql"
+)
+
+type Error struct {
+	Code    string
+	Message string
+	Desc    string
+}
+
+func (e *Error) Error() string {
+	message := e.Message
+	if e.Desc != "" {
+		message += ": " + e.Desc
+	}
+	return message
+}
+
+const (
+	ErrNotFoundCode           = "NotFound"
+	ErrAlreadyExistsCode      = "AlreadyExists"
+	ErrConstraintCode         = "Constraint"
+	ErrReferenceNotExistsCode = "ReferenceNotExists"
+	ErrNotEnoughBalanceCode   = "NotEnoughBalance"
+	ErrAccessDeniedCode       = "AccessDenied"
+	ErrIllegalStatusCode      = "IllegalStatus"
+	ErrInvalidPromotionCode   = "InvalidPromotion"
+	ErrNotOrderableCode       = "NotOrderable"
+	ErrOutOfStockCode         = "OutOfStock"
+)
+
+var (
+	ErrNotFound = &Error{
+		Code:    ErrNotFoundCode,
+		Message: "not found",
+	}
+	ErrAlreadyExists = &Error{
+		Code:    ErrAlreadyExistsCode,
+		Message: "already exists",
+	}
+	ErrConstraint = &Error{
+		Code:    ErrConstraintCode,
+		Message: "constraint failure",
+	}
+	ErrReferenceNotExists = &Error{
+		Code:    ErrReferenceNotExistsCode,
+		Message: "reference not exists",
+	}
+	ErrNotEnoughBalance = &Error{
+		Code:    ErrNotEnoughBalanceCode,
+		Message: "not enough balance",
+	}
+	ErrAccessDenied = &Error{
+		Code:    ErrAccessDeniedCode,
+		Message: "access denied",
+	}
+	ErrIllegalStatus = &Error{
+		Code:    ErrIllegalStatusCode,
+		Message: "illegal status",
+	}
+	ErrInvalidPromotion = &Error{
+		Code:    ErrInvalidPromotionCode,
+		Message: "invalid promotion",
+	}
+	ErrNotOrderable = &Error{
+		Code:    ErrNotOrderableCode,
+		Message: "dish is not orderable",
+	}
+	ErrOutOfStock = &Error{
+		Code:    ErrOutOfStockCode,
+		Message: "out of stock",
+	}
+)
+
+func (e *Error) WithDesc(desc string) *Error {
+	e.Desc = desc
+	return e
+}
+
+// checkTransition returns ErrIllegalStatus when dish order
+// status cannot be changed from to
+func checkTransition(from string, to string) error {
+	if err := mssql.CheckDishOrderTransition(from, to); err != nil {
+		return errors2.WithMessage(ErrIllegalStatus, err.Error())
+	}
+	return nil
+}
+
+func HandleStorageError(err error) error {
+	var ssmsErr *mssql.Error
+	if !errors.As(err, &ssmsErr) {
+		return errors2.WithMessage(err, "unknown error")
+	}
+	switch ssmsErr.Code {
+	case mssql.ErrNotFoundCode:
+		err = ErrNotFound
+	case mssql.ErrAlreadyExistsCode:
+		err = ErrAlreadyExists
+	case mssql.ErrReferenceNotExistsCode:
+		err = ErrReferenceNotExists
+	case mssql.ErrCheckFailedCode:
+		err = ErrConstraint
+	case mssql.ErrNotEnoughBalanceCode:
+		err = ErrNotEnoughBalance
+	case mssql.ErrOutOfStockCode:
+		err = ErrOutOfStock
+	default:
+		err = errors2.WithMessage(ssmsErr, "unknown mssql error")
+	}
+
+	return err
+}
@@ -0,0 +1,21 @@
+package orderDish
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) DishOrders(
+	ctx context.Context,
+	uid int64,
+) ([]models.DishOrder, error) {
+	const op = "services.pcClub.orderDish.DishOrders"
+
+	orders, err := s.provider.DishOrders(ctx, uid)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish orders from mssql")
+	}
+
+	return orders, nil
+}
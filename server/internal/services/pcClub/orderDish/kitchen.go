@@ -0,0 +1,109 @@
+package orderDish
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/storage/mssql"
+)
+
+// Delivery is where the order is handed to the user, orders placed
+// without an active pc session are picked up at the bar
+type Delivery struct {
+	Pickup   bool   `json:"pickup"`
+	PcRoomID int64  `json:"pc_room_id,omitempty"`
+	PcRoom   string `json:"pc_room,omitempty"`
+	Row      int    `json:"row,omitempty"`
+	Place    int    `json:"place,omitempty"`
+}
+
+// KitchenOrder is an order in the kitchen queue with its delivery place
+type KitchenOrder struct {
+	models.DishOrder
+	Delivery Delivery `json:"delivery"`
+}
+
+// KitchenQueue returns orders which are not delivered or cancelled yet, the oldest first
+func (s *Service) KitchenQueue(
+	ctx context.Context,
+) ([]KitchenOrder, error) {
+	const op = "services.pcClub.orderDish.KitchenQueue"
+
+	orders, err := s.provider.OpenDishOrders(ctx)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get open dish orders from mssql")
+	}
+
+	queue := make([]KitchenOrder, 0, len(orders))
+	for _, order := range orders {
+		queue = append(queue, kitchenOrder(order))
+	}
+
+	return queue, nil
+}
+
+// ChangeDishOrderStatus moves the order to the status on behalf of kitchen staff,
+// the cost of cancelled order is refunded to the user fully
+func (s *Service) ChangeDishOrderStatus(
+	ctx context.Context,
+	staffID int64,
+	orderID int64,
+	status string,
+) (KitchenOrder, error) {
+	const op = "services.pcClub.orderDish.ChangeDishOrderStatus"
+
+	order, err := s.provider.DishOrder(ctx, orderID)
+	if err != nil {
+		return KitchenOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish order from mssql")
+	}
+
+	from := order.DishOrderStatus.Name
+	if err := checkTransition(from, status); err != nil {
+		return KitchenOrder{}, errors2.WithMessage(err, op)
+	}
+
+	var refund money.Money
+	if status == mssql.CancelledDishOrderStatus {
+		refund = order.Cost
+	}
+
+	if err := s.owner.ChangeDishOrderStatus(ctx, orderID, order.UserID, from, status, staffID, refund); err != nil {
+		return KitchenOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to change dish order status in mssql")
+	}
+
+	if status == mssql.CancelledDishOrderStatus {
+		dishIDs := make([]int64, 0, len(order.DishOrderList))
+		for _, item := range order.DishOrderList {
+			dishIDs = append(dishIDs, item.DishID)
+		}
+		s.forgetDishes(ctx, op, dishIDs...)
+	}
+
+	order, err = s.provider.DishOrder(ctx, orderID)
+	if err != nil {
+		return KitchenOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get dish order from mssql")
+	}
+
+	return kitchenOrder(order), nil
+}
+
+func kitchenOrder(order models.DishOrder) KitchenOrder {
+	if order.PcOrderID == 0 {
+		return KitchenOrder{
+			DishOrder: order,
+			Delivery:  Delivery{Pickup: true},
+		}
+	}
+
+	pc := order.PcOrder.Pc
+	return KitchenOrder{
+		DishOrder: order,
+		Delivery: Delivery{
+			PcRoomID: pc.PcRoomID,
+			PcRoom:   pc.PcRoom.Name,
+			Row:      pc.Row,
+			Place:    pc.Place,
+		},
+	}
+}
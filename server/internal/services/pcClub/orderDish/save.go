@@ -0,0 +1,124 @@
+package orderDish
+
+import (
+	"context"
+	"errors"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/promotion"
+	"server/internal/storage/mssql"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Item is a dish with count in the order
+type Item struct {
+	DishID int64
+	Count  int16
+}
+
+// SaveDishOrder orders dishes paying them from users balance, counts of
+// repeated dishes are summed. Promo code is optional. It returns the order
+func (s *Service) SaveDishOrder(
+	ctx context.Context,
+	uid int64,
+	items []Item,
+	promoCode string,
+) (models.DishOrder, error) {
+	const op = "services.pcClub.orderDish.SaveDishOrder"
+
+	counts := make(map[int64]int16, len(items))
+	dishIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		if _, ok := counts[item.DishID]; !ok {
+			dishIDs = append(dishIDs, item.DishID)
+		}
+		counts[item.DishID] += item.Count
+	}
+
+	dishes, err := s.provider.DishesByIDs(ctx, dishIDs)
+	if err != nil {
+		return models.DishOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get dishes from mssql")
+	}
+	if len(dishes) != len(dishIDs) {
+		return models.DishOrder{}, errors2.WithMessage(ErrNotFound, op, "dish not found")
+	}
+
+	order := models.DishOrder{UserID: uid}
+	for _, dish := range dishes {
+		if !slices.Contains(mssql.OrderableDishStatuses, dish.DishStatus.Name) {
+			return models.DishOrder{}, errors2.WithMessage(ErrNotOrderable, op, dish.Name)
+		}
+
+		order.Cost += dish.Cost.Mul(int64(counts[dish.DishID]))
+		order.DishOrderList = append(order.DishOrderList, models.DishOrderList{
+			DishID: dish.DishID,
+			Count:  counts[dish.DishID],
+		})
+	}
+
+	redemption, err := s.applyPromotion(ctx, &order, dishes, counts, promoCode)
+	if err != nil {
+		return models.DishOrder{}, errors2.WithMessage(err, op, "failed to apply promotion")
+	}
+
+	if _, err := s.owner.SaveDishOrder(ctx, &order, redemption); err != nil {
+		return models.DishOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to save dish order in mssql")
+	}
+
+	s.forgetDishes(ctx, op, dishIDs...)
+
+	return order, nil
+}
+
+// applyPromotion takes discount of the promotion with the code off the
+// order cost, only dishes in the promotion scope are discounted.
+// It returns redemption to record or nil when code is empty or the
+// promotion gives no discount
+func (s *Service) applyPromotion(
+	ctx context.Context,
+	order *models.DishOrder,
+	dishes []models.Dish,
+	counts map[int64]int16,
+	code string,
+) (*models.PromotionRedemption, error) {
+	if code == "" {
+		return nil, nil
+	}
+
+	promo, err := s.promotionProvider.PromotionByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		err = HandleStorageError(err)
+		if errors.Is(err, ErrNotFound) {
+			return nil, errors2.WithMessage(ErrInvalidPromotion, "promotion not found")
+		}
+		return nil, errors2.WithMessage(err, "failed to get promotion from mssql")
+	}
+
+	if !promotion.IsActive(promo, time.Now()) {
+		return nil, errors2.WithMessage(ErrInvalidPromotion, "promotion is not active")
+	}
+
+	var scoped money.Money
+	for _, dish := range dishes {
+		if promotion.AppliesToDish(promo, dish.DishID) {
+			scoped += dish.Cost.Mul(int64(counts[dish.DishID]))
+		}
+	}
+	if scoped == 0 {
+		return nil, errors2.WithMessage(ErrInvalidPromotion, "promotion does not apply to the dishes")
+	}
+
+	discount := promotion.Discount(promo, scoped)
+	if discount == 0 {
+		return nil, nil
+	}
+	order.Cost -= discount
+
+	return &models.PromotionRedemption{
+		PromotionID: promo.PromotionID,
+		Amount:      discount,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package orderDish
+
+import (
+	"context"
+	"log/slog"
+	"server/internal/lib/api/logger/sl"
+	"server/internal/lib/money"
+	"server/internal/models"
+)
+
+type provider interface {
+	DishOrders(
+		ctx context.Context,
+		uid int64,
+	) (orders []models.DishOrder, err error)
+
+	DishOrder(
+		ctx context.Context,
+		orderID int64,
+	) (order models.DishOrder, err error)
+
+	OpenDishOrders(
+		ctx context.Context,
+	) (orders []models.DishOrder, err error)
+
+	DishesByIDs(
+		ctx context.Context,
+		dishIDs []int64,
+	) (dishes []models.Dish, err error)
+}
+
+type owner interface {
+	SaveDishOrder(
+		ctx context.Context,
+		order *models.DishOrder,
+		redemption *models.PromotionRedemption,
+	) (id int64, err error)
+
+	ChangeDishOrderStatus(
+		ctx context.Context,
+		orderID int64,
+		uid int64,
+		from string,
+		to string,
+		actorID int64,
+		refund money.Money,
+	) (err error)
+}
+
+type promotionProvider interface {
+	PromotionByCode(
+		ctx context.Context,
+		code string,
+	) (promotion models.Promotion, err error)
+}
+
+type dishCache interface {
+	Forget(
+		ctx context.Context,
+		dishIDs ...int64,
+	) (err error)
+}
+
+type Service struct {
+	log               *slog.Logger
+	provider          provider
+	owner             owner
+	promotionProvider promotionProvider
+	dishCache         dishCache
+}
+
+func New(
+	log *slog.Logger,
+	provider provider,
+	owner owner,
+	promotionProvider promotionProvider,
+	dishCache dishCache,
+) *Service {
+	return &Service{
+		log:               log,
+		provider:          provider,
+		owner:             owner,
+		promotionProvider: promotionProvider,
+		dishCache:         dishCache,
+	}
+}
+
+// forgetDishes drops cached dishes after the order is committed, orders take
+// dishes from stock and cancellations put them back. Failure is only logged,
+// the order has already been paid and cache entries expire
+func (s *Service) forgetDishes(ctx context.Context, op string, dishIDs ...int64) {
+	if err := s.dishCache.Forget(ctx, dishIDs...); err != nil {
+		s.log.Warn("failed to forget cached dishes", slog.String("operation", op), sl.Err(err))
+	}
+}
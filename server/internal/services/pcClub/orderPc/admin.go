@@ -0,0 +1,113 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/tariff"
+	"server/internal/storage/mssql"
+	"time"
+)
+
+// Report is a page of filtered orders with counts and cost sums of all
+// orders matching the filter
+type Report struct {
+	Orders   []models.PcOrder     `json:"orders"`
+	Count    int64                `json:"count"`
+	Cost     money.Money          `json:"cost"`
+	Statuses []mssql.PcOrderStats `json:"statuses"`
+}
+
+// PcOrdersReport returns orders of all users filtered by room, pc, user,
+// status and start time range, zero values are not filtered
+func (s *Service) PcOrdersReport(
+	ctx context.Context,
+	roomID int64,
+	pcID int64,
+	uid int64,
+	status string,
+	from time.Time,
+	to time.Time,
+	limit int,
+	offset int,
+) (Report, error) {
+	const op = "services.pcClub.orderPc.PcOrdersReport"
+
+	filter := mssql.PcOrderFilter{
+		RoomID: roomID,
+		PcID:   pcID,
+		UserID: uid,
+		Status: status,
+		From:   from,
+		To:     to,
+	}
+
+	orders, err := s.provider.FilterPcOrders(ctx, filter, limit, offset)
+	if err != nil {
+		return Report{}, errors2.WithMessage(HandleStorageError(err), op, "failed to filter pc orders in mssql")
+	}
+
+	stats, err := s.provider.PcOrderStats(ctx, filter)
+	if err != nil {
+		return Report{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order stats from mssql")
+	}
+
+	report := Report{
+		Orders:   orders,
+		Statuses: stats,
+	}
+	for _, stat := range stats {
+		report.Count += stat.Count
+		report.Cost += stat.Cost
+	}
+
+	return report, nil
+}
+
+// MovePcOrder moves booked order to another pc or time by admin, the order
+// keeps its duration and passes the same overlap check as booking. The cost
+// is repriced by tariffs of the new pc type and time, discounts the order got
+// are kept, and the difference is settled on the user balance
+func (s *Service) MovePcOrder(
+	ctx context.Context,
+	adminID int64,
+	orderID int64,
+	pcID int64,
+	startTime time.Time,
+) error {
+	const op = "services.pcClub.orderPc.MovePcOrder"
+
+	order, err := s.provider.PcOrder(ctx, orderID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order from mssql")
+	}
+
+	if order.PcOrderStatus.Name != mssql.BookedPcOrderStatus {
+		return errors2.WithMessage(ErrIllegalStatus, op, "pc order is not booked")
+	}
+
+	pc, err := s.pcProvider.Pc(ctx, pcID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to get pc from mssql")
+	}
+
+	cost := movedCost(order, pc.PcType, startTime)
+	if err := s.owner.MovePcOrder(ctx, orderID, adminID, pcID, startTime, cost); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to move pc order in mssql")
+	}
+
+	return nil
+}
+
+// movedCost returns cost of the order moved to the pc type and start time:
+// the order cost changed by the tariff price difference of the old and
+// the new booking, so discounts the order got keep their amount
+func movedCost(order models.PcOrder, pcType models.PcType, startTime time.Time) money.Money {
+	length := time.Duration(order.Duration) * time.Minute
+	oldType := order.Pc.PcType
+	oldPrice := tariff.Calculate(oldType.HourCost, oldType.PcTypeTariffs, order.StartTime, length).Cost
+	newPrice := tariff.Calculate(pcType.HourCost, pcType.PcTypeTariffs, startTime, length).Cost
+
+	return max(order.Cost+newPrice-oldPrice, 0)
+}
@@ -0,0 +1,92 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/storage/mssql"
+	"time"
+)
+
+// CancelPcOrder cancels users own future order and refunds
+// its cost by the refund policy, it returns refunded amount
+func (s *Service) CancelPcOrder(
+	ctx context.Context,
+	uid int64,
+	orderID int64,
+) (money.Money, error) {
+	const op = "services.pcClub.orderPc.CancelPcOrder"
+
+	order, err := s.provider.PcOrder(ctx, orderID)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order from mssql")
+	}
+
+	if order.UserID != uid {
+		return 0, errors2.WithMessage(ErrAccessDenied, op, "pc order belongs to another user")
+	}
+
+	untilStart := time.Until(order.StartTime)
+	if untilStart <= 0 {
+		return 0, errors2.WithMessage(ErrConstraint, op, "pc order has already started")
+	}
+
+	return s.cancel(ctx, op, order, uid, s.refund(order.Cost, untilStart))
+}
+
+// CancelPcOrderByAdmin cancels any booked order, the cost is refunded
+// fully or by the refund policy. It returns refunded amount
+func (s *Service) CancelPcOrderByAdmin(
+	ctx context.Context,
+	adminID int64,
+	orderID int64,
+	fullRefund bool,
+) (money.Money, error) {
+	const op = "services.pcClub.orderPc.CancelPcOrderByAdmin"
+
+	order, err := s.provider.PcOrder(ctx, orderID)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order from mssql")
+	}
+
+	refund := order.Cost
+	if !fullRefund {
+		refund = s.refund(order.Cost, time.Until(order.StartTime))
+	}
+
+	return s.cancel(ctx, op, order, adminID, refund)
+}
+
+func (s *Service) cancel(
+	ctx context.Context,
+	op string,
+	order models.PcOrder,
+	actorID int64,
+	refund money.Money,
+) (money.Money, error) {
+	if err := checkTransition(order.PcOrderStatus.Name, mssql.CancelledPcOrderStatus); err != nil {
+		return 0, errors2.WithMessage(err, op)
+	}
+
+	if err := s.owner.CancelPcOrder(ctx, order.PcOrderID, order.UserID, actorID, refund); err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to cancel pc order in mssql")
+	}
+
+	return refund, nil
+}
+
+// refund returns part of the cost by the tier with the longest
+// period which is not longer than time left until the order start
+func (s *Service) refund(cost money.Money, untilStart time.Duration) money.Money {
+	percent := 0
+	var longest time.Duration = -1
+	for _, tier := range s.cfg.Refunds {
+		if tier.Before <= untilStart && tier.Before > longest {
+			longest = tier.Before
+			percent = tier.Percent
+		}
+	}
+
+	return cost.Percent(int64(percent))
+}
@@ -0,0 +1,62 @@
+package orderPc
+
+import (
+	"server/internal/config"
+	"server/internal/lib/money"
+	"testing"
+	"time"
+)
+
+func TestRefund(t *testing.T) {
+	tiers := &config.PcOrderConfig{
+		Refunds: []config.RefundTierConfig{
+			{Before: time.Hour, Percent: 50},
+			{Before: 24 * time.Hour, Percent: 100},
+			{Before: 3 * time.Hour, Percent: 75},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		cfg        *config.PcOrderConfig
+		cost       money.Money
+		untilStart time.Duration
+		want       money.Money
+	}{
+		{name: "no tier reached", cost: 10000, untilStart: 30 * time.Minute, want: 0},
+		{name: "tier boundary", cost: 10000, untilStart: time.Hour, want: 5000},
+		{name: "between tiers", cost: 10000, untilStart: 2 * time.Hour, want: 5000},
+		{name: "tiers are not sorted", cost: 10000, untilStart: 5 * time.Hour, want: 7500},
+		{name: "longest tier", cost: 10000, untilStart: 48 * time.Hour, want: 10000},
+		{name: "rounded to minor units", cost: 333, untilStart: 2 * time.Hour, want: 167},
+		{name: "already started", cost: 10000, untilStart: -time.Minute, want: 0},
+		{
+			name:       "no tiers",
+			cfg:        &config.PcOrderConfig{},
+			cost:       10000,
+			untilStart: 48 * time.Hour,
+			want:       0,
+		},
+		{
+			name:       "zero tier refunds started order",
+			cfg:        &config.PcOrderConfig{Refunds: []config.RefundTierConfig{{Before: 0, Percent: 10}}},
+			cost:       10000,
+			untilStart: 0,
+			want:       1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			if cfg == nil {
+				cfg = tiers
+			}
+			s := &Service{cfg: cfg}
+
+			if got := s.refund(tt.cost, tt.untilStart); got != tt.want {
+				t.Errorf("refund(%s, %s) = %s, want %s", tt.cost, tt.untilStart, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+	"time"
+)
+
+func (s *Service) CheckInPcOrder(
+	ctx context.Context,
+	pcID int64,
+	code string,
+) (models.PcOrder, error) {
+	const op = "services.pcClub.orderPc.CheckInPcOrder"
+
+	now := time.Now()
+	order, err := s.owner.CheckInPcOrder(
+		ctx,
+		pcID,
+		code,
+		now.Add(-s.cfg.GracePeriod),
+		now.Add(s.cfg.EarlyCheckIn),
+	)
+	if err != nil {
+		return models.PcOrder{}, errors2.WithMessage(HandleStorageError(err), op, "failed to check in pc order in mssql")
+	}
+
+	return order, nil
+}
@@ -1,6 +1,10 @@
 package orderPc
 
-import "server/internal/storage/mssql"
+import (
+	"errors"
+	errors2 "server/internal/lib/errors"
+	"server/internal/storage/mssql"
+)
 
 type Error struct {
 	Code    string
@@ -21,6 +25,10 @@ const (
 	ErrAlreadyExistsCode      = "AlreadyExists"
 	ErrConstraintCode         = "Constraint"
 	ErrReferenceNotExistsCode = "ReferenceNotExists"
+	ErrNotEnoughBalanceCode   = "NotEnoughBalance"
+	ErrAccessDeniedCode       = "AccessDenied"
+	ErrIllegalStatusCode      = "IllegalStatus"
+	ErrInvalidPromotionCode   = "InvalidPromotion"
 )
 
 var (
@@ -40,6 +48,22 @@ var (
 		Code:    ErrReferenceNotExistsCode,
 		Message: "reference not exists",
 	}
+	ErrNotEnoughBalance = &Error{
+		Code:    ErrNotEnoughBalanceCode,
+		Message: "not enough balance",
+	}
+	ErrAccessDenied = &Error{
+		Code:    ErrAccessDeniedCode,
+		Message: "access denied",
+	}
+	ErrIllegalStatus = &Error{
+		Code:    ErrIllegalStatusCode,
+		Message: "illegal status",
+	}
+	ErrInvalidPromotion = &Error{
+		Code:    ErrInvalidPromotionCode,
+		Message: "invalid promotion",
+	}
 )
 
 func (e *Error) WithDesc(desc string) *Error {
@@ -47,19 +71,34 @@ func (e *Error) WithDesc(desc string) *Error {
 	return e
 }
 
-func handleStorageError(ssmsErr *mssql.Error) *Error {
+// checkTransition returns ErrIllegalStatus when pc order
+// status cannot be changed from to
+func checkTransition(from string, to string) error {
+	if err := mssql.CheckPcOrderTransition(from, to); err != nil {
+		return errors2.WithMessage(ErrIllegalStatus, err.Error())
+	}
+	return nil
+}
+
+func HandleStorageError(err error) error {
+	var ssmsErr *mssql.Error
+	if !errors.As(err, &ssmsErr) {
+		return errors2.WithMessage(err, "unknown error")
+	}
 	switch ssmsErr.Code {
 	case mssql.ErrNotFoundCode:
-		return ErrNotFound
+		err = ErrNotFound
 	case mssql.ErrAlreadyExistsCode:
-		return ErrAlreadyExists
+		err = ErrAlreadyExists
 	case mssql.ErrReferenceNotExistsCode:
-		return ErrReferenceNotExists
+		err = ErrReferenceNotExists
 	case mssql.ErrCheckFailedCode:
-		return ErrConstraint
+		err = ErrConstraint
+	case mssql.ErrNotEnoughBalanceCode:
+		err = ErrNotEnoughBalance
 	default:
-		return &Error{
-			Message: ssmsErr.Message,
-		}
+		err = errors2.WithMessage(ssmsErr, "unknown mssql error")
 	}
+
+	return err
 }
@@ -0,0 +1,32 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"time"
+)
+
+// ExpireNoShowPcOrders marks booked orders without check in
+// during grace period as no show
+func (s *Service) ExpireNoShowPcOrders(ctx context.Context) (int64, error) {
+	const op = "services.pcClub.orderPc.ExpireNoShowPcOrders"
+
+	count, err := s.owner.ExpirePcOrders(ctx, time.Now().Add(-s.cfg.GracePeriod))
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to expire pc orders in mssql")
+	}
+
+	return count, nil
+}
+
+// FinishEndedPcOrders closes active orders which end time has passed
+func (s *Service) FinishEndedPcOrders(ctx context.Context) (int64, error) {
+	const op = "services.pcClub.orderPc.FinishEndedPcOrders"
+
+	count, err := s.owner.FinishPcOrders(ctx, time.Now())
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to finish pc orders in mssql")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,21 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) PcOrders(
+	ctx context.Context,
+	uid int64,
+) ([]models.PcOrder, error) {
+	const op = "services.pcClub.orderPc.PcOrders"
+
+	orders, err := s.provider.PcOrders(ctx, uid)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc orders from mssql")
+	}
+
+	return orders, nil
+}
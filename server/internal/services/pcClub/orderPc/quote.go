@@ -0,0 +1,247 @@
+package orderPc
+
+import (
+	"context"
+	"errors"
+	"math"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/promotion"
+	"server/internal/services/pcClub/tariff"
+	"strings"
+	"time"
+)
+
+// Discount is an amount taken off the order cost
+type Discount struct {
+	Name   string      `json:"name"`
+	Amount money.Money `json:"amount"`
+}
+
+// PackageUsage is minutes of the order covered by user hour package
+type PackageUsage struct {
+	UserHourPackageID int64  `json:"user_hour_package_id"`
+	Name              string `json:"name"`
+	Minutes           int    `json:"minutes"`
+}
+
+// Quote is itemised price of an order, it is used both to show
+// the price before booking and to charge the booking itself
+type Quote struct {
+	PcTypeID     int64            `json:"pc_type_id"`
+	StartTime    time.Time        `json:"start_time"`
+	EndTime      time.Time        `json:"end_time"`
+	Duration     int16            `json:"duration"`
+	BaseHourCost money.Money      `json:"base_hour_cost"`
+	BaseHours    float64          `json:"base_hours"`
+	Segments     []tariff.Segment `json:"segments"`
+	Packages     []PackageUsage   `json:"packages"`
+	Discounts    []Discount       `json:"discounts"`
+	Cost         money.Money      `json:"cost"`
+	Total        money.Money      `json:"total"`
+	PromotionID  int64            `json:"promotion_id,omitempty"`
+	Balance      money.Money      `json:"balance"`
+	Sufficient   bool             `json:"sufficient"`
+
+	promotionDiscount money.Money
+}
+
+// QuotePcOrder returns price of the order for pc or, when pc is not
+// chosen yet, for pc type without creating the order. Promo code is optional
+func (s *Service) QuotePcOrder(
+	ctx context.Context,
+	uid int64,
+	pcID int64,
+	typeID int64,
+	startTime time.Time,
+	duration int16,
+	promoCode string,
+) (Quote, error) {
+	const op = "services.pcClub.orderPc.QuotePcOrder"
+
+	var pcType models.PcType
+	var roomID int64
+	if pcID != 0 {
+		pc, err := s.pcProvider.Pc(ctx, pcID)
+		if err != nil {
+			return Quote{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc from mssql")
+		}
+		pcType = pc.PcType
+		roomID = pc.PcRoomID
+	} else {
+		var err error
+		pcType, err = s.pcProvider.PcType(ctx, typeID)
+		if err != nil {
+			return Quote{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc type from mssql")
+		}
+
+		pcType.PcTypeTariffs, err = s.pcProvider.PcTypeTariffs(ctx, typeID)
+		if err != nil {
+			return Quote{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc type tariffs from mssql")
+		}
+	}
+
+	user, err := s.userProvider.User(ctx, uid)
+	if err != nil {
+		return Quote{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get user from mssql")
+	}
+
+	wallet, err := s.userProvider.UserHourPackages(ctx, uid)
+	if err != nil {
+		return Quote{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get user hour packages from mssql")
+	}
+
+	quote := newQuote(pcType, wallet, startTime, duration)
+	if err := s.applyPromotion(ctx, &quote, promoCode, roomID); err != nil {
+		return Quote{}, errors2.WithMessage(err, op, "failed to apply promotion")
+	}
+	quote.Balance = user.Balance
+	quote.Sufficient = user.Balance >= quote.Total
+
+	return quote, nil
+}
+
+// newQuote calculates price of the order by pc type tariffs, time
+// inside windows of suitable wallet packages is taken from them first
+func newQuote(
+	pcType models.PcType,
+	wallet []models.UserHourPackage,
+	startTime time.Time,
+	duration int16,
+) Quote {
+	length := time.Duration(duration) * time.Minute
+	calculation := tariff.Calculate(pcType.HourCost, pcType.PcTypeTariffs, startTime, length)
+
+	var baseHours float64
+	for _, segment := range calculation.Segments {
+		if segment.TariffID == 0 {
+			baseHours += segment.End.Sub(segment.Start).Hours()
+		}
+	}
+
+	quote := Quote{
+		PcTypeID:     pcType.PcTypeID,
+		StartTime:    startTime,
+		EndTime:      startTime.Add(length),
+		Duration:     duration,
+		BaseHourCost: pcType.HourCost,
+		BaseHours:    math.Round(baseHours*100) / 100,
+		Segments:     calculation.Segments,
+		Packages:     []PackageUsage{},
+		Discounts:    []Discount{},
+		Cost:         calculation.Cost,
+	}
+
+	uncovered := []tariff.Part{{Start: quote.StartTime, End: quote.EndTime}}
+	for _, userPackage := range wallet {
+		if userPackage.HourPackage.PcTypeID != pcType.PcTypeID || userPackage.Minutes <= 0 {
+			continue
+		}
+
+		var covered []tariff.Part
+		covered, uncovered = coverByPackage(userPackage, uncovered)
+		if len(covered) == 0 {
+			continue
+		}
+
+		var minutes time.Duration
+		var amount money.Money
+		for _, part := range covered {
+			minutes += part.End.Sub(part.Start)
+			amount += tariff.Calculate(pcType.HourCost, pcType.PcTypeTariffs, part.Start, part.End.Sub(part.Start)).Cost
+		}
+
+		quote.Packages = append(quote.Packages, PackageUsage{
+			UserHourPackageID: userPackage.UserHourPackageID,
+			Name:              userPackage.HourPackage.Name,
+			Minutes:           min(int(math.Ceil(minutes.Minutes())), userPackage.Minutes),
+		})
+		quote.Discounts = append(quote.Discounts, Discount{
+			Name:   userPackage.HourPackage.Name,
+			Amount: amount,
+		})
+	}
+
+	quote.Total = quote.Cost
+	for _, discount := range quote.Discounts {
+		quote.Total -= discount.Amount
+	}
+	quote.Total = max(quote.Total, 0)
+
+	return quote
+}
+
+// applyPromotion takes discount of the promotion with the code off the
+// quote total. Empty code is ignored, promotion giving no discount
+// is not redeemed
+func (s *Service) applyPromotion(
+	ctx context.Context,
+	quote *Quote,
+	code string,
+	roomID int64,
+) error {
+	if code == "" {
+		return nil
+	}
+
+	promo, err := s.promotionProvider.PromotionByCode(ctx, strings.ToUpper(code))
+	if err != nil {
+		err = HandleStorageError(err)
+		if errors.Is(err, ErrNotFound) {
+			return errors2.WithMessage(ErrInvalidPromotion, "promotion not found")
+		}
+		return errors2.WithMessage(err, "failed to get promotion from mssql")
+	}
+
+	if !promotion.IsActive(promo, time.Now()) {
+		return errors2.WithMessage(ErrInvalidPromotion, "promotion is not active")
+	}
+	if !promotion.AppliesToPc(promo, quote.PcTypeID, roomID) {
+		return errors2.WithMessage(ErrInvalidPromotion, "promotion does not apply to the pc")
+	}
+
+	discount := promotion.Discount(promo, quote.Total)
+	if discount == 0 {
+		return nil
+	}
+	quote.Discounts = append(quote.Discounts, Discount{
+		Name:   promo.Name,
+		Amount: discount,
+	})
+	quote.Total -= discount
+	quote.PromotionID = promo.PromotionID
+	quote.promotionDiscount = discount
+
+	return nil
+}
+
+// coverByPackage takes time inside the package window from the
+// uncovered parts while package minutes last, it returns taken
+// parts and parts left uncovered
+func coverByPackage(
+	userPackage models.UserHourPackage,
+	uncovered []tariff.Part,
+) (covered []tariff.Part, left []tariff.Part) {
+	hourPackage := userPackage.HourPackage
+	remaining := time.Duration(userPackage.Minutes) * time.Minute
+
+	for _, part := range uncovered {
+		for _, piece := range tariff.Split(hourPackage.StartMinute, hourPackage.EndMinute, part.Start, part.End) {
+			if !piece.Inside || remaining <= 0 {
+				left = append(left, tariff.Part{Start: piece.Start, End: piece.End})
+				continue
+			}
+
+			end := piece.End
+			if piece.End.Sub(piece.Start) > remaining {
+				end = piece.Start.Add(remaining)
+				left = append(left, tariff.Part{Start: end, End: piece.End})
+			}
+			covered = append(covered, tariff.Part{Start: piece.Start, End: end})
+			remaining -= end.Sub(piece.Start)
+		}
+	}
+
+	return covered, left
+}
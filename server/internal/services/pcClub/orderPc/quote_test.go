@@ -0,0 +1,200 @@
+package orderPc
+
+import (
+	"server/internal/lib/money"
+	"server/internal/models"
+	"testing"
+	"time"
+)
+
+func TestNewQuote(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	local := time.Local
+	time.Local = msk
+	t.Cleanup(func() {
+		time.Local = local
+	})
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, msk)
+	}
+
+	pcType := models.PcType{
+		PcTypeID: 1,
+		HourCost: 10000,
+	}
+	nightType := pcType
+	nightType.PcTypeTariffs = []models.PcTypeTariff{{
+		PcTypeTariffID: 1,
+		StartMinute:    22 * 60,
+		EndMinute:      6 * 60,
+		Weekdays:       1<<7 - 1,
+		HourCost:       5000,
+	}}
+
+	userPackage := func(id int64, typeID int64, startMinute, endMinute int16, minutes int) models.UserHourPackage {
+		return models.UserHourPackage{
+			UserHourPackageID: id,
+			Minutes:           minutes,
+			HourPackage: models.HourPackage{
+				PcTypeID:    typeID,
+				Name:        "package",
+				StartMinute: startMinute,
+				EndMinute:   endMinute,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		pcType    models.PcType
+		wallet    []models.UserHourPackage
+		start     time.Time
+		duration  int16
+		cost      money.Money
+		total     money.Money
+		baseHours float64
+		minutes   []int
+		discounts []money.Money
+	}{
+		{
+			name:      "no packages",
+			pcType:    pcType,
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     20000,
+			baseHours: 2,
+		},
+		{
+			name:      "package of other pc type",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 2, 0, 0, 600)},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     20000,
+			baseHours: 2,
+		},
+		{
+			name:      "used up package",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 0, 0, 0)},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     20000,
+			baseHours: 2,
+		},
+		{
+			name:      "package covers whole order",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 0, 0, 600)},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     0,
+			baseHours: 2,
+			minutes:   []int{120},
+			discounts: []money.Money{20000},
+		},
+		{
+			name:      "package minutes run out",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 0, 0, 30)},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     15000,
+			baseHours: 2,
+			minutes:   []int{30},
+			discounts: []money.Money{5000},
+		},
+		{
+			name:      "order leaves package window",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 10*60, 13*60, 600)},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     10000,
+			baseHours: 2,
+			minutes:   []int{60},
+			discounts: []money.Money{10000},
+		},
+		{
+			name:   "next package covers the rest",
+			pcType: pcType,
+			wallet: []models.UserHourPackage{
+				userPackage(1, 1, 0, 0, 30),
+				userPackage(2, 1, 0, 0, 600),
+			},
+			start:     at(12, 0),
+			duration:  120,
+			cost:      20000,
+			total:     0,
+			baseHours: 2,
+			minutes:   []int{30, 90},
+			discounts: []money.Money{5000, 15000},
+		},
+		{
+			name:      "package pays covered time by tariff",
+			pcType:    nightType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 22*60, 6*60, 600)},
+			start:     at(21, 0),
+			duration:  120,
+			cost:      15000,
+			total:     10000,
+			baseHours: 1,
+			minutes:   []int{60},
+			discounts: []money.Money{5000},
+		},
+		{
+			name:      "partial minute is rounded up",
+			pcType:    pcType,
+			wallet:    []models.UserHourPackage{userPackage(1, 1, 0, 0, 600)},
+			start:     at(12, 0).Add(30 * time.Second),
+			duration:  1,
+			cost:      167,
+			total:     0,
+			baseHours: 0.02,
+			minutes:   []int{1},
+			discounts: []money.Money{167},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newQuote(tt.pcType, tt.wallet, tt.start, tt.duration)
+
+			if got.Cost != tt.cost {
+				t.Errorf("Cost = %s, want %s", got.Cost, tt.cost)
+			}
+			if got.Total != tt.total {
+				t.Errorf("Total = %s, want %s", got.Total, tt.total)
+			}
+			if got.BaseHours != tt.baseHours {
+				t.Errorf("BaseHours = %v, want %v", got.BaseHours, tt.baseHours)
+			}
+			if !got.EndTime.Equal(tt.start.Add(time.Duration(tt.duration) * time.Minute)) {
+				t.Errorf("EndTime = %s, want start plus duration", got.EndTime)
+			}
+			if len(got.Packages) != len(tt.minutes) {
+				t.Fatalf("got %d packages, want %d: %+v", len(got.Packages), len(tt.minutes), got.Packages)
+			}
+			for i, minutes := range tt.minutes {
+				if got.Packages[i].Minutes != minutes {
+					t.Errorf("package %d minutes = %d, want %d", i, got.Packages[i].Minutes, minutes)
+				}
+			}
+			if len(got.Discounts) != len(tt.discounts) {
+				t.Fatalf("got %d discounts, want %d: %+v", len(got.Discounts), len(tt.discounts), got.Discounts)
+			}
+			for i, amount := range tt.discounts {
+				if got.Discounts[i].Amount != amount {
+					t.Errorf("discount %d = %s, want %s", i, got.Discounts[i].Amount, amount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package orderPc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/random"
+	"server/internal/models"
+	"time"
+)
+
+func (s *Service) SavePcOrder(
+	ctx context.Context,
+	uid int64,
+	pcID int64,
+	startTime time.Time,
+	duration int16,
+	promoCode string,
+) (string, error) {
+	const op = "services.pcClub.orderPc.SavePcOrder"
+
+	pc, err := s.pcProvider.Pc(ctx, pcID)
+	if err != nil {
+		return "", errors2.WithMessage(HandleStorageError(err), op, "failed to get pc from mssql")
+	}
+
+	wallet, err := s.userProvider.UserHourPackages(ctx, uid)
+	if err != nil {
+		return "", errors2.WithMessage(HandleStorageError(err), op, "failed to get user hour packages from mssql")
+	}
+	quote := newQuote(pc.PcType, wallet, startTime, duration)
+	if err := s.applyPromotion(ctx, &quote, promoCode, pc.PcRoomID); err != nil {
+		return "", errors2.WithMessage(err, op, "failed to apply promotion")
+	}
+
+	code, err := random.Code(codeLength)
+	if err != nil {
+		return "", errors2.WithMessage(err, op, "failed to generate order code")
+	}
+
+	order := models.PcOrder{
+		UserID:        uid,
+		PcID:          pcID,
+		Code:          code,
+		Cost:          quote.Total,
+		StartTime:     startTime,
+		Duration:      duration,
+		ActualEndTime: startTime.Add(time.Duration(duration) * time.Minute),
+	}
+	usages := make([]models.PcOrderHourPackage, 0, len(quote.Packages))
+	for _, usage := range quote.Packages {
+		usages = append(usages, models.PcOrderHourPackage{
+			UserHourPackageID: usage.UserHourPackageID,
+			Minutes:           usage.Minutes,
+		})
+	}
+	var redemption *models.PromotionRedemption
+	if quote.PromotionID != 0 {
+		redemption = &models.PromotionRedemption{
+			PromotionID: quote.PromotionID,
+			Amount:      quote.promotionDiscount,
+		}
+	}
+	if _, err := s.owner.SavePcOrder(ctx, &order, usages, redemption); err != nil {
+		return "", errors2.WithMessage(HandleStorageError(err), op, "failed to save pc order in mssql")
+	}
+
+	return code, nil
+}
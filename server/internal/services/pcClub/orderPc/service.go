@@ -1 +1,160 @@
 package orderPc
+
+import (
+	"context"
+	"server/internal/config"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/storage/mssql"
+	"time"
+)
+
+type provider interface {
+	PcOrders(
+		ctx context.Context,
+		uid int64,
+	) (orders []models.PcOrder, err error)
+
+	PcOrder(
+		ctx context.Context,
+		orderID int64,
+	) (order models.PcOrder, err error)
+
+	FilterPcOrders(
+		ctx context.Context,
+		filter mssql.PcOrderFilter,
+		limit int,
+		offset int,
+	) (orders []models.PcOrder, err error)
+
+	PcOrderStats(
+		ctx context.Context,
+		filter mssql.PcOrderFilter,
+	) (stats []mssql.PcOrderStats, err error)
+}
+
+type owner interface {
+	SavePcOrder(
+		ctx context.Context,
+		order *models.PcOrder,
+		usages []models.PcOrderHourPackage,
+		redemption *models.PromotionRedemption,
+	) (id int64, err error)
+
+	CheckInPcOrder(
+		ctx context.Context,
+		pcID int64,
+		code string,
+		from time.Time,
+		to time.Time,
+	) (order models.PcOrder, err error)
+
+	CancelPcOrder(
+		ctx context.Context,
+		orderID int64,
+		uid int64,
+		actorID int64,
+		refund money.Money,
+	) (err error)
+
+	ExtendPcOrder(
+		ctx context.Context,
+		orderID int64,
+		uid int64,
+		minutes int16,
+		cost money.Money,
+	) (err error)
+
+	EndPcOrder(
+		ctx context.Context,
+		orderID int64,
+		uid int64,
+		end time.Time,
+		refund money.Money,
+		unusedMinutes int16,
+	) (err error)
+
+	MovePcOrder(
+		ctx context.Context,
+		orderID int64,
+		adminID int64,
+		pcID int64,
+		startTime time.Time,
+		cost money.Money,
+	) (err error)
+
+	ExpirePcOrders(
+		ctx context.Context,
+		before time.Time,
+	) (count int64, err error)
+
+	FinishPcOrders(
+		ctx context.Context,
+		before time.Time,
+	) (count int64, err error)
+}
+
+type pcProvider interface {
+	Pc(
+		ctx context.Context,
+		pcID int64,
+	) (pc models.Pc, err error)
+
+	PcType(
+		ctx context.Context,
+		typeID int64,
+	) (pcType models.PcType, err error)
+
+	PcTypeTariffs(
+		ctx context.Context,
+		typeID int64,
+	) (tariffs []models.PcTypeTariff, err error)
+}
+
+type userProvider interface {
+	User(
+		ctx context.Context,
+		uid int64,
+	) (user models.User, err error)
+
+	UserHourPackages(
+		ctx context.Context,
+		uid int64,
+	) (packages []models.UserHourPackage, err error)
+}
+
+type promotionProvider interface {
+	PromotionByCode(
+		ctx context.Context,
+		code string,
+	) (promotion models.Promotion, err error)
+}
+
+type Service struct {
+	cfg               *config.PcOrderConfig
+	provider          provider
+	owner             owner
+	pcProvider        pcProvider
+	userProvider      userProvider
+	promotionProvider promotionProvider
+}
+
+const codeLength = 8
+
+func New(
+	cfg *config.PcOrderConfig,
+	provider provider,
+	owner owner,
+	pcProvider pcProvider,
+	userProvider userProvider,
+	promotionProvider promotionProvider,
+) *Service {
+	return &Service{
+		cfg:               cfg,
+		provider:          provider,
+		owner:             owner,
+		pcProvider:        pcProvider,
+		userProvider:      userProvider,
+		promotionProvider: promotionProvider,
+	}
+}
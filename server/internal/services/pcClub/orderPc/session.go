@@ -0,0 +1,99 @@
+package orderPc
+
+import (
+	"context"
+	"math"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"server/internal/services/pcClub/tariff"
+	"server/internal/storage/mssql"
+	"time"
+)
+
+// ExtendPcOrder prolongs users active order by minutes,
+// it returns the cost of extra time
+func (s *Service) ExtendPcOrder(
+	ctx context.Context,
+	uid int64,
+	orderID int64,
+	minutes int16,
+) (money.Money, error) {
+	const op = "services.pcClub.orderPc.ExtendPcOrder"
+
+	order, err := s.provider.PcOrder(ctx, orderID)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order from mssql")
+	}
+
+	if order.UserID != uid {
+		return 0, errors2.WithMessage(ErrAccessDenied, op, "pc order belongs to another user")
+	}
+
+	if order.PcOrderStatus.Name != mssql.ActivePcOrderStatus {
+		return 0, errors2.WithMessage(ErrIllegalStatus, op, "pc order is not active")
+	}
+
+	if !order.ActualEndTime.After(time.Now()) {
+		return 0, errors2.WithMessage(ErrConstraint, op, "pc order has already ended")
+	}
+
+	if int(order.Duration)+int(minutes) > math.MaxInt16 {
+		return 0, errors2.WithMessage(ErrConstraint, op, "pc order duration is too long")
+	}
+
+	pcType := order.Pc.PcType
+	cost := tariff.Calculate(pcType.HourCost, pcType.PcTypeTariffs, order.ActualEndTime, time.Duration(minutes)*time.Minute).Cost
+	if err := s.owner.ExtendPcOrder(ctx, orderID, uid, minutes, cost); err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to extend pc order in mssql")
+	}
+
+	return cost, nil
+}
+
+// EndPcOrder finishes users active order now. When it is enabled in config
+// unused whole minutes are returned in proportion to what was paid for them:
+// the share of the order cost and the share of used hour package minutes.
+// It returns refunded amount
+func (s *Service) EndPcOrder(
+	ctx context.Context,
+	uid int64,
+	orderID int64,
+) (money.Money, error) {
+	const op = "services.pcClub.orderPc.EndPcOrder"
+
+	order, err := s.provider.PcOrder(ctx, orderID)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc order from mssql")
+	}
+
+	if order.UserID != uid {
+		return 0, errors2.WithMessage(ErrAccessDenied, op, "pc order belongs to another user")
+	}
+
+	if err := checkTransition(order.PcOrderStatus.Name, mssql.FinishedPcOrderStatus); err != nil {
+		return 0, errors2.WithMessage(err, op)
+	}
+
+	now := time.Now()
+	refund, unusedMinutes := s.endRefund(order, now)
+
+	if err := s.owner.EndPcOrder(ctx, orderID, uid, now, refund, unusedMinutes); err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to end pc order in mssql")
+	}
+
+	return refund, nil
+}
+
+// endRefund returns the order cost share of whole minutes left until
+// the order end at now and count of these minutes, nothing is returned
+// when refund of unused time is disabled
+func (s *Service) endRefund(order models.PcOrder, now time.Time) (money.Money, int16) {
+	unused := order.ActualEndTime.Sub(now)
+	if !s.cfg.RefundUnused || unused <= 0 || order.Duration <= 0 {
+		return 0, 0
+	}
+
+	unusedMinutes := int16(min(int64(unused/time.Minute), int64(order.Duration)))
+	return order.Cost.MulDiv(int64(unusedMinutes), int64(order.Duration)), unusedMinutes
+}
@@ -0,0 +1,53 @@
+package orderPc
+
+import (
+	"server/internal/config"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"testing"
+	"time"
+)
+
+func TestEndRefund(t *testing.T) {
+	start := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	order := models.PcOrder{
+		Cost:          12000,
+		StartTime:     start,
+		Duration:      120,
+		ActualEndTime: start.Add(2 * time.Hour),
+	}
+	extended := order
+	extended.Duration = 180
+	extended.ActualEndTime = start.Add(3 * time.Hour)
+
+	tests := []struct {
+		name     string
+		disabled bool
+		order    models.PcOrder
+		now      time.Time
+		refund   money.Money
+		minutes  int16
+	}{
+		{name: "before start", order: order, now: start.Add(-time.Hour), refund: 12000, minutes: 120},
+		{name: "just started", order: order, now: start, refund: 12000, minutes: 120},
+		{name: "half used", order: order, now: start.Add(time.Hour), refund: 6000, minutes: 60},
+		{name: "partial minute is not refunded", order: order, now: start.Add(90*time.Minute + 30*time.Second), refund: 2900, minutes: 29},
+		{name: "less than a minute left", order: order, now: order.ActualEndTime.Add(-30 * time.Second), refund: 0, minutes: 0},
+		{name: "ended", order: order, now: order.ActualEndTime, refund: 0, minutes: 0},
+		{name: "overtime", order: order, now: order.ActualEndTime.Add(time.Hour), refund: 0, minutes: 0},
+		{name: "extended order", order: extended, now: start.Add(2 * time.Hour), refund: 4000, minutes: 60},
+		{name: "disabled", disabled: true, order: order, now: start.Add(time.Hour), refund: 0, minutes: 0},
+		{name: "zero duration", order: models.PcOrder{Cost: 100, ActualEndTime: start}, now: start.Add(-time.Hour), refund: 0, minutes: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{cfg: &config.PcOrderConfig{RefundUnused: !tt.disabled}}
+
+			refund, minutes := s.endRefund(tt.order, tt.now)
+			if refund != tt.refund || minutes != tt.minutes {
+				t.Errorf("endRefund() = %s, %d, want %s, %d", refund, minutes, tt.refund, tt.minutes)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) Payments(
+	ctx context.Context,
+	uid int64,
+	limit int,
+	offset int,
+) ([]models.Payment, error) {
+	const op = "services.pcClub.payment.Payments"
+
+	list, err := s.provider.Payments(ctx, uid, limit, offset)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get payments from mssql")
+	}
+	if list == nil {
+		list = []models.Payment{}
+	}
+
+	return list, nil
+}
+
+// Payment returns users own payment, it is polled after
+// returning from the provider checkout
+func (s *Service) Payment(
+	ctx context.Context,
+	uid int64,
+	paymentID int64,
+) (models.Payment, error) {
+	const op = "services.pcClub.payment.Payment"
+
+	payment, err := s.provider.Payment(ctx, paymentID)
+	if err != nil {
+		return models.Payment{}, errors2.WithMessage(HandleStorageError(err), op, "failed to get payment from mssql")
+	}
+
+	if payment.UserID != uid {
+		return models.Payment{}, errors2.WithMessage(ErrNotFound, op, "payment belongs to another user")
+	}
+
+	return payment, nil
+}
@@ -0,0 +1,33 @@
+package payment
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+// RefundPayment returns succeeded top-up to the user at the provider and
+// debits its amount from the balance, it fails when the amount is spent
+func (s *Service) RefundPayment(
+	ctx context.Context,
+	adminID int64,
+	paymentID int64,
+) (models.Payment, error) {
+	const op = "services.pcClub.payment.RefundPayment"
+
+	if !s.Enabled() {
+		return models.Payment{}, errors2.WithMessage(ErrProvider, op, "payments are disabled")
+	}
+
+	payment, err := s.owner.RefundPayment(ctx, paymentID, adminID, func(payment models.Payment) error {
+		if err := s.gateway.Refund(ctx, payment.ExternalID, payment.Amount); err != nil {
+			return errors2.WithMessage(ErrProvider, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Payment{}, errors2.WithMessage(HandleStorageError(err), op, "failed to refund payment in mssql")
+	}
+
+	return payment, nil
+}
@@ -0,0 +1,86 @@
+package payment
+
+import (
+	"context"
+	"server/internal/config"
+	"server/internal/lib/money"
+	"server/internal/lib/payments"
+	"server/internal/models"
+)
+
+type provider interface {
+	Payments(
+		ctx context.Context,
+		uid int64,
+		limit int,
+		offset int,
+	) (payments []models.Payment, err error)
+
+	Payment(
+		ctx context.Context,
+		paymentID int64,
+	) (payment models.Payment, err error)
+}
+
+type owner interface {
+	SavePayment(
+		ctx context.Context,
+		payment *models.Payment,
+	) (id int64, err error)
+
+	RegisterPayment(
+		ctx context.Context,
+		paymentID int64,
+		externalID string,
+		redirectURL string,
+	) (err error)
+
+	FailPayment(
+		ctx context.Context,
+		paymentID int64,
+	) (err error)
+
+	CompletePayment(
+		ctx context.Context,
+		provider string,
+		paymentID int64,
+		externalID string,
+		eventID string,
+		status string,
+		amount money.Money,
+	) (payment models.Payment, applied bool, err error)
+
+	RefundPayment(
+		ctx context.Context,
+		paymentID int64,
+		adminID int64,
+		refund func(payment models.Payment) error,
+	) (payment models.Payment, err error)
+}
+
+type Service struct {
+	cfg      *config.PaymentsConfig
+	gateway  payments.Provider
+	provider provider
+	owner    owner
+}
+
+func New(
+	cfg *config.PaymentsConfig,
+	gateway payments.Provider,
+	provider provider,
+	owner owner,
+) *Service {
+	return &Service{
+		cfg:      cfg,
+		gateway:  gateway,
+		provider: provider,
+		owner:    owner,
+	}
+}
+
+// Enabled reports whether payment provider is configured,
+// without it only existing payments can be read
+func (s *Service) Enabled() bool {
+	return s.gateway != nil
+}
@@ -0,0 +1,51 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/lib/payments"
+	"server/internal/models"
+)
+
+// TopUp creates pending payment for the amount and registers it at the
+// provider. User has to pay it following returned redirect url, the balance
+// is credited only when the provider confirms payment by webhook
+func (s *Service) TopUp(
+	ctx context.Context,
+	uid int64,
+	amount money.Money,
+) (models.Payment, error) {
+	const op = "services.pcClub.payment.TopUp"
+
+	payment := models.Payment{
+		UserID:   uid,
+		Provider: s.gateway.Name(),
+		Amount:   amount,
+	}
+	if _, err := s.owner.SavePayment(ctx, &payment); err != nil {
+		return models.Payment{}, errors2.WithMessage(HandleStorageError(err), op, "failed to save payment in mssql")
+	}
+
+	created, err := s.gateway.CreatePayment(ctx, payments.Request{
+		PaymentID:   payment.PaymentID,
+		Amount:      amount,
+		Description: fmt.Sprintf("Balance top-up #%d", payment.PaymentID),
+		ReturnURL:   s.cfg.ReturnURL,
+	})
+	if err != nil {
+		if failErr := s.owner.FailPayment(ctx, payment.PaymentID); failErr != nil {
+			err = fmt.Errorf("%w; failed to fail payment in mssql: %w", err, failErr)
+		}
+		return models.Payment{}, errors2.WithMessage(ErrProvider, op, "failed to create payment at provider", err.Error())
+	}
+
+	if err := s.owner.RegisterPayment(ctx, payment.PaymentID, created.ExternalID, created.RedirectURL); err != nil {
+		return models.Payment{}, errors2.WithMessage(HandleStorageError(err), op, "failed to register payment in mssql")
+	}
+	payment.ExternalID = created.ExternalID
+	payment.RedirectURL = created.RedirectURL
+
+	return payment, nil
+}
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/payments"
+	"server/internal/models"
+	"server/internal/storage/mssql"
+)
+
+// HandleWebhook verifies the provider callback and applies its result to
+// the payment. Callbacks repeating an already applied result change nothing,
+// applied reports whether this callback changed the payment
+func (s *Service) HandleWebhook(
+	ctx context.Context,
+	r *http.Request,
+) (payment models.Payment, applied bool, err error) {
+	const op = "services.pcClub.payment.HandleWebhook"
+
+	event, err := s.gateway.Webhook(r)
+	if err != nil {
+		switch {
+		case errors.Is(err, payments.ErrInvalidSignature):
+			return models.Payment{}, false, errors2.WithMessage(ErrInvalidSignature, op, err.Error())
+		case errors.Is(err, payments.ErrInvalidEvent):
+			return models.Payment{}, false, errors2.WithMessage(ErrInvalidEvent, op, err.Error())
+		}
+		return models.Payment{}, false, errors2.WithMessage(err, op, "failed to read webhook")
+	}
+
+	status := mssql.FailedPaymentStatus
+	if event.Status == payments.StatusSucceeded {
+		status = mssql.SucceededPaymentStatus
+	}
+
+	payment, applied, err = s.owner.CompletePayment(ctx, s.gateway.Name(), event.PaymentID, event.ExternalID, event.EventID, status, event.Amount)
+	if err != nil {
+		return models.Payment{}, false, errors2.WithMessage(HandleStorageError(err), op, "failed to complete payment in mssql")
+	}
+
+	return payment, applied, nil
+}
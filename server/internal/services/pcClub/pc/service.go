@@ -3,6 +3,7 @@ package pc
 import (
 	"context"
 	"server/internal/models"
+	"time"
 )
 
 type provider interface {
@@ -11,6 +12,14 @@ type provider interface {
 		typeID int64,
 		isAvailable bool,
 	) (pcs []models.Pc, err error)
+
+	PcsWithOrders(
+		ctx context.Context,
+		typeID int64,
+		roomID int64,
+		from time.Time,
+		to time.Time,
+	) (pcs []models.Pc, err error)
 }
 
 type owner interface {
@@ -0,0 +1,97 @@
+package pc
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+	"time"
+)
+
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type Timeline struct {
+	PcID     int64      `json:"pc_id"`
+	PcRoomID int64      `json:"pc_room_id"`
+	PcTypeID int64      `json:"pc_type_id"`
+	Row      int        `json:"row"`
+	Place    int        `json:"place"`
+	Free     []Interval `json:"free"`
+	Busy     []Interval `json:"busy"`
+}
+
+func (s *Service) PcsTimeline(
+	ctx context.Context,
+	typeID int64,
+	roomID int64,
+	from time.Time,
+	to time.Time,
+) ([]Timeline, error) {
+	const op = "services.pcClub.pc.PcsTimeline"
+
+	pcs, err := s.provider.PcsWithOrders(ctx, typeID, roomID, from, to)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get pcs with orders from mssql")
+	}
+
+	timelines := make([]Timeline, 0, len(pcs))
+	for _, pc := range pcs {
+		busy := busyIntervals(pc.PcOrders, from, to)
+		timelines = append(timelines, Timeline{
+			PcID:     pc.PcID,
+			PcRoomID: pc.PcRoomID,
+			PcTypeID: pc.PcTypeID,
+			Row:      pc.Row,
+			Place:    pc.Place,
+			Free:     freeIntervals(busy, from, to),
+			Busy:     busy,
+		})
+	}
+
+	return timelines, nil
+}
+
+// busyIntervals returns merged intervals of orders sorted by start time
+// clipped by [from, to)
+func busyIntervals(orders []models.PcOrder, from time.Time, to time.Time) []Interval {
+	busy := make([]Interval, 0, len(orders))
+	for _, order := range orders {
+		start, end := order.StartTime, order.ActualEndTime
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		if last := len(busy) - 1; last >= 0 && !start.After(busy[last].End) {
+			if end.After(busy[last].End) {
+				busy[last].End = end
+			}
+			continue
+		}
+		busy = append(busy, Interval{Start: start, End: end})
+	}
+	return busy
+}
+
+// freeIntervals returns gaps between sorted busy intervals within [from, to)
+func freeIntervals(busy []Interval, from time.Time, to time.Time) []Interval {
+	free := make([]Interval, 0, len(busy)+1)
+	cursor := from
+	for _, interval := range busy {
+		if cursor.Before(interval.Start) {
+			free = append(free, Interval{Start: cursor, End: interval.Start})
+		}
+		cursor = interval.End
+	}
+	if cursor.Before(to) {
+		free = append(free, Interval{Start: cursor, End: to})
+	}
+	return free
+}
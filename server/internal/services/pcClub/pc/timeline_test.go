@@ -0,0 +1,116 @@
+package pc
+
+import (
+	"server/internal/models"
+	"testing"
+	"time"
+)
+
+var day = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hour int) time.Time {
+	return day.Add(time.Duration(hour) * time.Hour)
+}
+
+func order(start, end int) models.PcOrder {
+	return models.PcOrder{StartTime: at(start), ActualEndTime: at(end)}
+}
+
+func interval(start, end int) Interval {
+	return Interval{Start: at(start), End: at(end)}
+}
+
+func equalIntervals(a, b []Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBusyIntervals(t *testing.T) {
+	tests := []struct {
+		name   string
+		orders []models.PcOrder
+		want   []Interval
+	}{
+		{name: "no orders", want: []Interval{}},
+		{
+			name:   "separate orders",
+			orders: []models.PcOrder{order(10, 12), order(14, 15)},
+			want:   []Interval{interval(10, 12), interval(14, 15)},
+		},
+		{
+			name:   "adjacent orders are merged",
+			orders: []models.PcOrder{order(10, 12), order(12, 13)},
+			want:   []Interval{interval(10, 13)},
+		},
+		{
+			name:   "overlapping orders are merged",
+			orders: []models.PcOrder{order(10, 13), order(11, 14)},
+			want:   []Interval{interval(10, 14)},
+		},
+		{
+			name:   "nested order",
+			orders: []models.PcOrder{order(10, 16), order(11, 12), order(13, 14)},
+			want:   []Interval{interval(10, 16)},
+		},
+		{
+			name:   "clipped by range",
+			orders: []models.PcOrder{order(6, 10), order(20, 26)},
+			want:   []Interval{interval(8, 10), interval(20, 22)},
+		},
+		{
+			name:   "outside range",
+			orders: []models.PcOrder{order(5, 8), order(22, 23)},
+			want:   []Interval{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := busyIntervals(tt.orders, at(8), at(22))
+			if !equalIntervals(got, tt.want) {
+				t.Errorf("busyIntervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFreeIntervals(t *testing.T) {
+	tests := []struct {
+		name string
+		busy []Interval
+		want []Interval
+	}{
+		{name: "no busy", want: []Interval{interval(8, 22)}},
+		{
+			name: "busy inside",
+			busy: []Interval{interval(10, 12), interval(14, 15)},
+			want: []Interval{interval(8, 10), interval(12, 14), interval(15, 22)},
+		},
+		{
+			name: "busy at range bounds",
+			busy: []Interval{interval(8, 10), interval(20, 22)},
+			want: []Interval{interval(10, 20)},
+		},
+		{
+			name: "busy whole range",
+			busy: []Interval{interval(8, 22)},
+			want: []Interval{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := freeIntervals(tt.busy, at(8), at(22))
+			if !equalIntervals(got, tt.want) {
+				t.Errorf("freeIntervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
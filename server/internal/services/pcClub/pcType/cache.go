@@ -0,0 +1,33 @@
+package pcType
+
+import (
+	"context"
+	"fmt"
+	errors2 "server/internal/lib/errors"
+)
+
+const listCachePattern = "pc_types:*"
+
+// CacheKey returns redis key of the cached pc type
+func CacheKey(typeID int64) string {
+	return fmt.Sprintf("pc_type:%d", typeID)
+}
+
+// ListCacheKey returns redis key of the cached page of pc types
+func ListCacheKey(limit int, offset int) string {
+	return fmt.Sprintf("pc_types:%d-%d", limit, offset)
+}
+
+// Forget drops cached pc types and all cached pc type lists,
+// it is called when pc types change outside of this service
+func (s *Service) Forget(ctx context.Context, typeIDs ...int64) error {
+	for _, typeID := range typeIDs {
+		if err := s.redisOwner.Delete(ctx, CacheKey(typeID)); err != nil {
+			return errors2.WithMessage(err, "failed to delete pc type from redis")
+		}
+	}
+	if err := s.redisOwner.DeleteByPattern(ctx, listCachePattern); err != nil {
+		return errors2.WithMessage(err, "failed to delete pc types from redis")
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package pcType
 import (
 	"context"
 	"errors"
-	"fmt"
 	errors2 "server/internal/lib/errors"
 	"server/internal/models"
 	"server/internal/storage/redis"
@@ -19,7 +18,7 @@ func (s *Service) PcTypes(
 	var pcTypes []models.PcType
 	err := s.redisProvider.Value(
 		ctx,
-		fmt.Sprintf("pc_types:%d-%d", limit, offset),
+		ListCacheKey(limit, offset),
 		&pcTypes,
 	)
 	if err == nil {
@@ -36,7 +35,7 @@ func (s *Service) PcTypes(
 
 	err = s.redisOwner.Set(
 		ctx,
-		fmt.Sprintf("pc_types:%d-%d", limit, offset),
+		ListCacheKey(limit, offset),
 		pcTypes,
 	)
 	if err != nil {
@@ -55,7 +54,7 @@ func (s *Service) PcType(
 	var pcType models.PcType
 	err := s.redisProvider.Value(
 		ctx,
-		fmt.Sprintf("pc_type:%d", typeID),
+		CacheKey(typeID),
 		&pcType,
 	)
 	if err == nil {
@@ -72,7 +71,7 @@ func (s *Service) PcType(
 
 	err = s.redisOwner.Set(
 		ctx,
-		fmt.Sprintf("pc_type:%d", typeID),
+		CacheKey(typeID),
 		pcType,
 	)
 	if err != nil {
@@ -0,0 +1,30 @@
+package pcTypeImage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/images"
+)
+
+// DeletePcTypeImage deletes the image and its files
+func (s *Service) DeletePcTypeImage(
+	ctx context.Context,
+	imageID int64,
+) error {
+	const op = "services.pcClub.pcTypeImage.DeletePcTypeImage"
+
+	typeImage, err := s.owner.DeletePcTypeImage(ctx, imageID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete pc type image from mssql")
+	}
+
+	if err := images.RemoveAll(s.cfg.Path, typeImage.Path); err != nil {
+		return errors2.WithMessage(err, op, "failed to remove image files")
+	}
+
+	if err := s.pcTypeCache.Forget(ctx, typeImage.PcTypeID); err != nil {
+		return errors2.WithMessage(err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package pcTypeImage
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) PcTypeImages(
+	ctx context.Context,
+	typeID int64,
+) ([]models.PcTypeImage, error) {
+	const op = "services.pcClub.pcTypeImage.PcTypeImages"
+
+	typeImages, err := s.provider.PcTypeImages(ctx, typeID)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc type images from mssql")
+	}
+
+	return typeImages, nil
+}
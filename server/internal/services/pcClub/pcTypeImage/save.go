@@ -0,0 +1,114 @@
+package pcTypeImage
+
+import (
+	"context"
+	"errors"
+	"path"
+	errors2 "server/internal/lib/errors"
+	"server/internal/lib/images"
+	"server/internal/models"
+)
+
+// SavePcTypeImage stores the image file with its resized and WebP variants under the
+// configured path and appends it to the pc type gallery, the image type is sniffed from its content.
+// Path of the saved image is its public url
+func (s *Service) SavePcTypeImage(
+	ctx context.Context,
+	typeID int64,
+	data []byte,
+	isMain bool,
+) (models.PcTypeImage, error) {
+	const op = "services.pcClub.pcTypeImage.SavePcTypeImage"
+
+	if int64(len(data)) > s.cfg.MaxSize {
+		return models.PcTypeImage{}, errors2.WithMessage(ErrTooLarge, op)
+	}
+
+	ext, err := images.Extension(data)
+	if err != nil {
+		if errors.Is(err, images.ErrUnsupportedType) {
+			return models.PcTypeImage{}, errors2.WithMessage(ErrUnsupportedType, op)
+		}
+		return models.PcTypeImage{}, errors2.WithMessage(err, op, "failed to sniff image type")
+	}
+
+	name, err := images.Save(s.cfg.Path, data, ext)
+	if err != nil {
+		return models.PcTypeImage{}, errors2.WithMessage(err, op, "failed to write image file")
+	}
+
+	width, height, err := images.Generate(s.cfg.Path, name, data)
+	if err != nil {
+		_ = images.RemoveAll(s.cfg.Path, name)
+		return models.PcTypeImage{}, errors2.WithMessage(variantsError(err), op, "failed to generate image variants")
+	}
+
+	typeImage := models.PcTypeImage{
+		PcTypeID: typeID,
+		IsMain:   isMain,
+		Path:     path.Join(s.cfg.UrlPath, name),
+		Width:    width,
+		Height:   height,
+	}
+	if _, err := s.owner.SavePcTypeImage(ctx, &typeImage); err != nil {
+		_ = images.RemoveAll(s.cfg.Path, name)
+		return models.PcTypeImage{}, errors2.WithMessage(HandleStorageError(err), op, "failed to save pc type image in mssql")
+	}
+
+	if err := s.pcTypeCache.Forget(ctx, typeID); err != nil {
+		return models.PcTypeImage{}, errors2.WithMessage(err, op)
+	}
+
+	return typeImage, nil
+}
+
+// ReorderPcTypeImages sets gallery order of the pc type,
+// imageIDs must contain every image of the pc type
+func (s *Service) ReorderPcTypeImages(
+	ctx context.Context,
+	typeID int64,
+	imageIDs []int64,
+) error {
+	const op = "services.pcClub.pcTypeImage.ReorderPcTypeImages"
+
+	if err := s.owner.ReorderPcTypeImages(ctx, typeID, imageIDs); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to reorder pc type images in mssql")
+	}
+
+	if err := s.pcTypeCache.Forget(ctx, typeID); err != nil {
+		return errors2.WithMessage(err, op)
+	}
+
+	return nil
+}
+
+// SetMainPcTypeImage makes the image main for its pc type
+func (s *Service) SetMainPcTypeImage(
+	ctx context.Context,
+	imageID int64,
+) error {
+	const op = "services.pcClub.pcTypeImage.SetMainPcTypeImage"
+
+	typeImage, err := s.owner.SetMainPcTypeImage(ctx, imageID)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to set main pc type image in mssql")
+	}
+
+	if err := s.pcTypeCache.Forget(ctx, typeImage.PcTypeID); err != nil {
+		return errors2.WithMessage(err, op)
+	}
+
+	return nil
+}
+
+// variantsError maps errors of image decoding to service errors
+func variantsError(err error) error {
+	switch {
+	case errors.Is(err, images.ErrUnsupportedType):
+		return ErrUnsupportedType
+	case errors.Is(err, images.ErrTooManyPixels):
+		return ErrTooLarge
+	default:
+		return err
+	}
+}
@@ -0,0 +1,65 @@
+package pcTypeImage
+
+import (
+	"context"
+	"server/internal/config"
+	"server/internal/models"
+)
+
+type provider interface {
+	PcTypeImages(
+		ctx context.Context,
+		typeID int64,
+	) (images []models.PcTypeImage, err error)
+}
+
+type owner interface {
+	SavePcTypeImage(
+		ctx context.Context,
+		typeImage *models.PcTypeImage,
+	) (id int64, err error)
+
+	ReorderPcTypeImages(
+		ctx context.Context,
+		typeID int64,
+		imageIDs []int64,
+	) (err error)
+
+	SetMainPcTypeImage(
+		ctx context.Context,
+		imageID int64,
+	) (typeImage models.PcTypeImage, err error)
+
+	DeletePcTypeImage(
+		ctx context.Context,
+		imageID int64,
+	) (typeImage models.PcTypeImage, err error)
+}
+
+type pcTypeCache interface {
+	Forget(
+		ctx context.Context,
+		typeIDs ...int64,
+	) (err error)
+}
+
+type Service struct {
+	cfg         *config.PCSImagesConfig
+	provider    provider
+	owner       owner
+	pcTypeCache pcTypeCache
+}
+
+func New(
+	cfg *config.PCSImagesConfig,
+	provider provider,
+	owner owner,
+	pcTypeCache pcTypeCache,
+) *Service {
+	return &Service{
+		cfg:         cfg,
+		provider:    provider,
+		owner:       owner,
+		pcTypeCache: pcTypeCache,
+	}
+}
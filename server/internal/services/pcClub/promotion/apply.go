@@ -0,0 +1,84 @@
+package promotion
+
+import (
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+// IsActive reports whether the promotion validity window contains the time
+// and its global usage limit is not reached. Per user limit is checked
+// by the storage while redeeming
+func IsActive(promotion models.Promotion, at time.Time) bool {
+	if at.Before(promotion.StartDate) || !at.Before(promotion.EndDate) {
+		return false
+	}
+	return promotion.UsageLimit == 0 || promotion.UsageCount < promotion.UsageLimit
+}
+
+// AppliesToPc reports whether pc of the type in the room is in the
+// promotion scope. Empty scope list does not restrict, promotions
+// scoped to dishes only are not applied to pcs. Zero room id means
+// that the room is not known yet and only room unrestricted promotions fit
+func AppliesToPc(promotion models.Promotion, typeID int64, roomID int64) bool {
+	if len(promotion.PromotionDishes) != 0 &&
+		len(promotion.PromotionPcTypes) == 0 &&
+		len(promotion.PromotionPcRooms) == 0 {
+		return false
+	}
+
+	if len(promotion.PromotionPcTypes) != 0 && !containsPcType(promotion.PromotionPcTypes, typeID) {
+		return false
+	}
+
+	if len(promotion.PromotionPcRooms) != 0 && !containsPcRoom(promotion.PromotionPcRooms, roomID) {
+		return false
+	}
+
+	return true
+}
+
+// AppliesToDish reports whether the dish is in the promotion scope,
+// promotions scoped to pcs only are not applied to dishes
+func AppliesToDish(promotion models.Promotion, dishID int64) bool {
+	if len(promotion.PromotionDishes) == 0 {
+		return len(promotion.PromotionPcTypes) == 0 && len(promotion.PromotionPcRooms) == 0
+	}
+
+	for _, dish := range promotion.PromotionDishes {
+		if dish.DishID == dishID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount returns amount taken off the cost by the promotion,
+// it is never greater than the cost
+func Discount(promotion models.Promotion, cost money.Money) money.Money {
+	discount := promotion.Amount
+	if promotion.Percent != 0 {
+		discount = cost.Percent(int64(promotion.Percent))
+	}
+
+	return min(discount, cost)
+}
+
+func containsPcType(types []models.PromotionPcType, typeID int64) bool {
+	for _, pcType := range types {
+		if pcType.PcTypeID == typeID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPcRoom(rooms []models.PromotionPcRoom, roomID int64) bool {
+	for _, room := range rooms {
+		if room.PcRoomID == roomID {
+			return true
+		}
+	}
+	return false
+}
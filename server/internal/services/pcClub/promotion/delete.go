@@ -0,0 +1,19 @@
+package promotion
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+)
+
+func (s *Service) DeletePromotion(
+	ctx context.Context,
+	promotionID int64,
+) error {
+	const op = "services.pcClub.promotion.DeletePromotion"
+
+	if err := s.owner.DeletePromotion(ctx, promotionID); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete promotion from mssql")
+	}
+
+	return nil
+}
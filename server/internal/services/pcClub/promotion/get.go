@@ -0,0 +1,20 @@
+package promotion
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) Promotions(
+	ctx context.Context,
+) ([]models.Promotion, error) {
+	const op = "services.pcClub.promotion.Promotions"
+
+	promotions, err := s.provider.Promotions(ctx)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get promotions from mssql")
+	}
+
+	return promotions, nil
+}
@@ -0,0 +1,23 @@
+package promotion
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+	"strings"
+)
+
+func (s *Service) SavePromotion(
+	ctx context.Context,
+	promotion *models.Promotion,
+) (int64, error) {
+	const op = "services.pcClub.promotion.SavePromotion"
+
+	promotion.Code = strings.ToUpper(promotion.Code)
+	id, err := s.owner.SavePromotion(ctx, promotion)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to save promotion in mssql")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,42 @@
+package promotion
+
+import (
+	"context"
+	"server/internal/models"
+)
+
+type provider interface {
+	Promotions(
+		ctx context.Context,
+	) (promotions []models.Promotion, err error)
+}
+
+type owner interface {
+	SavePromotion(
+		ctx context.Context,
+		promotion *models.Promotion,
+	) (id int64, err error)
+
+	UpdatePromotion(
+		ctx context.Context,
+		promotionID int64,
+		promotion *models.Promotion,
+	) (err error)
+
+	DeletePromotion(
+		ctx context.Context,
+		promotionID int64,
+	) (err error)
+}
+
+type Service struct {
+	provider provider
+	owner    owner
+}
+
+func New(provider provider, owner owner) *Service {
+	return &Service{
+		provider: provider,
+		owner:    owner,
+	}
+}
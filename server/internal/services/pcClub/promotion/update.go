@@ -0,0 +1,23 @@
+package promotion
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+	"strings"
+)
+
+func (s *Service) UpdatePromotion(
+	ctx context.Context,
+	promotionID int64,
+	promotion *models.Promotion,
+) error {
+	const op = "services.pcClub.promotion.UpdatePromotion"
+
+	promotion.Code = strings.ToUpper(promotion.Code)
+	if err := s.owner.UpdatePromotion(ctx, promotionID, promotion); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to update promotion in mssql")
+	}
+
+	return nil
+}
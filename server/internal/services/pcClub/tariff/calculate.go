@@ -0,0 +1,174 @@
+package tariff
+
+import (
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+const allWeekdays = 1<<7 - 1
+
+// Segment is a part of the order time charged by a single tariff,
+// TariffID is zero when base pc type hour cost is used
+type Segment struct {
+	Start    time.Time   `json:"start"`
+	End      time.Time   `json:"end"`
+	TariffID int64       `json:"tariff_id,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	HourCost money.Money `json:"hour_cost"`
+	Cost     money.Money `json:"cost"`
+}
+
+type Calculation struct {
+	Segments []Segment   `json:"segments"`
+	Cost     money.Money `json:"cost"`
+}
+
+// Calculate splits time from start with given duration by tariff
+// boundaries and returns cost of every part. Tariffs are matched in
+// the given order, the first matching wins. Time not covered by any
+// tariff is charged by base hour cost
+func Calculate(
+	baseHourCost money.Money,
+	tariffs []models.PcTypeTariff,
+	start time.Time,
+	duration time.Duration,
+) Calculation {
+	var calculation Calculation
+
+	start = start.In(time.Local)
+	end := start.Add(duration)
+	for current := start; current.Before(end); {
+		tariff := matchTariff(tariffs, current)
+		next := nextBoundary(tariffs, current)
+		if next.After(end) {
+			next = end
+		}
+
+		last := len(calculation.Segments) - 1
+		if last >= 0 && calculation.Segments[last].TariffID == tariffID(tariff) {
+			calculation.Segments[last].End = next
+		} else {
+			segment := Segment{
+				Start:    current,
+				End:      next,
+				HourCost: baseHourCost,
+			}
+			if tariff != nil {
+				segment.TariffID = tariff.PcTypeTariffID
+				segment.Name = tariff.Name
+				segment.HourCost = tariff.HourCost
+			}
+			calculation.Segments = append(calculation.Segments, segment)
+		}
+
+		current = next
+	}
+
+	for i := range calculation.Segments {
+		segment := &calculation.Segments[i]
+		segment.Cost = cost(segment.HourCost, segment.End.Sub(segment.Start))
+		calculation.Cost += segment.Cost
+	}
+
+	return calculation
+}
+
+// matchTariff returns first tariff acting at the given time or nil
+func matchTariff(tariffs []models.PcTypeTariff, at time.Time) *models.PcTypeTariff {
+	minute := int16(at.Hour()*60 + at.Minute())
+	weekday := at.Weekday()
+
+	for i := range tariffs {
+		tariff := &tariffs[i]
+
+		switch {
+		case tariff.StartMinute == tariff.EndMinute:
+			if onWeekday(tariff, weekday) {
+				return tariff
+			}
+		case tariff.StartMinute < tariff.EndMinute:
+			if minute >= tariff.StartMinute && minute < tariff.EndMinute && onWeekday(tariff, weekday) {
+				return tariff
+			}
+		default:
+			// range passes midnight, so its morning part belongs
+			// to the range started the day before
+			if minute >= tariff.StartMinute && onWeekday(tariff, weekday) {
+				return tariff
+			}
+			if minute < tariff.EndMinute && onWeekday(tariff, (weekday+6)%7) {
+				return tariff
+			}
+		}
+	}
+
+	return nil
+}
+
+// nextBoundary returns the nearest time after the given one at which
+// the acting tariff may change
+func nextBoundary(tariffs []models.PcTypeTariff, after time.Time) time.Time {
+	day := time.Date(after.Year(), after.Month(), after.Day(), 0, 0, 0, 0, after.Location())
+	next := day.AddDate(0, 0, 1)
+
+	for _, tariff := range tariffs {
+		for _, minute := range []int16{tariff.StartMinute, tariff.EndMinute} {
+			boundary := day.Add(time.Duration(minute) * time.Minute)
+			if boundary.After(after) && boundary.Before(next) {
+				next = boundary
+			}
+		}
+	}
+
+	return next
+}
+
+// onWeekday reports whether tariff acts on the weekday,
+// weekdays are stored as bit mask where Sunday is the lowest bit
+func onWeekday(tariff *models.PcTypeTariff, weekday time.Weekday) bool {
+	return tariff.Weekdays&(1<<weekday) != 0
+}
+
+func tariffID(tariff *models.PcTypeTariff) int64 {
+	if tariff == nil {
+		return 0
+	}
+	return tariff.PcTypeTariffID
+}
+
+// cost returns cost of duration by hour cost rounded to minor units
+func cost(hourCost money.Money, duration time.Duration) money.Money {
+	return hourCost.MulDiv(int64(duration), int64(time.Hour))
+}
+
+// Part is a piece of time lying inside or outside a daily range
+type Part struct {
+	Start  time.Time
+	End    time.Time
+	Inside bool
+}
+
+// Split splits time from start to end by the daily range from start
+// minute to end minute acting on every weekday, ranges are treated
+// the same way as tariff ones
+func Split(startMinute, endMinute int16, start, end time.Time) []Part {
+	window := []models.PcTypeTariff{{
+		PcTypeTariffID: 1,
+		StartMinute:    startMinute,
+		EndMinute:      endMinute,
+		Weekdays:       allWeekdays,
+	}}
+
+	calculation := Calculate(0, window, start, end.Sub(start))
+	parts := make([]Part, 0, len(calculation.Segments))
+	for _, segment := range calculation.Segments {
+		parts = append(parts, Part{
+			Start:  segment.Start,
+			End:    segment.End,
+			Inside: segment.TariffID != 0,
+		})
+	}
+
+	return parts
+}
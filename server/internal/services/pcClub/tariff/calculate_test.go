@@ -0,0 +1,287 @@
+package tariff
+
+import (
+	"server/internal/lib/money"
+	"server/internal/models"
+	"testing"
+	"time"
+)
+
+const (
+	sunday   = 1 << time.Sunday
+	monday   = 1 << time.Monday
+	saturday = 1 << time.Saturday
+)
+
+// useLocal sets local time zone for the test, tariffs act in local time
+func useLocal(t *testing.T, loc *time.Location) {
+	t.Helper()
+
+	local := time.Local
+	time.Local = loc
+	t.Cleanup(func() {
+		time.Local = local
+	})
+}
+
+func TestCalculate(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	useLocal(t, msk)
+
+	// 2024-01-01 is Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, msk)
+	}
+
+	night := models.PcTypeTariff{
+		PcTypeTariffID: 1,
+		Name:           "night",
+		StartMinute:    22 * 60,
+		EndMinute:      6 * 60,
+		Weekdays:       allWeekdays,
+		HourCost:       5000,
+	}
+	mondayNight := night
+	mondayNight.Weekdays = monday
+	sundayNight := night
+	sundayNight.Weekdays = sunday
+	weekend := models.PcTypeTariff{
+		PcTypeTariffID: 2,
+		Name:           "weekend",
+		Weekdays:       saturday | sunday,
+		HourCost:       15000,
+	}
+	evening := models.PcTypeTariff{
+		PcTypeTariffID: 3,
+		Name:           "evening",
+		StartMinute:    18 * 60,
+		EndMinute:      23 * 60,
+		Weekdays:       allWeekdays,
+		HourCost:       20000,
+	}
+
+	type segment struct {
+		start    time.Time
+		end      time.Time
+		tariffID int64
+		cost     money.Money
+	}
+
+	tests := []struct {
+		name     string
+		tariffs  []models.PcTypeTariff
+		start    time.Time
+		duration time.Duration
+		want     []segment
+		cost     money.Money
+	}{
+		{
+			name:     "no tariffs",
+			start:    at(1, 12, 0),
+			duration: 90 * time.Minute,
+			want:     []segment{{at(1, 12, 0), at(1, 13, 30), 0, 15000}},
+			cost:     15000,
+		},
+		{
+			name:     "base then tariff",
+			tariffs:  []models.PcTypeTariff{night},
+			start:    at(1, 21, 0),
+			duration: 3 * time.Hour,
+			want: []segment{
+				{at(1, 21, 0), at(1, 22, 0), 0, 10000},
+				{at(1, 22, 0), at(2, 0, 0), 1, 10000},
+			},
+			cost: 20000,
+		},
+		{
+			name:     "range passing midnight is one segment",
+			tariffs:  []models.PcTypeTariff{mondayNight},
+			start:    at(1, 23, 0),
+			duration: 2 * time.Hour,
+			want:     []segment{{at(1, 23, 0), at(2, 1, 0), 1, 10000}},
+			cost:     10000,
+		},
+		{
+			name:     "morning part belongs to the day before",
+			tariffs:  []models.PcTypeTariff{sundayNight},
+			start:    at(1, 5, 0),
+			duration: 2 * time.Hour,
+			want: []segment{
+				{at(1, 5, 0), at(1, 6, 0), 1, 5000},
+				{at(1, 6, 0), at(1, 7, 0), 0, 10000},
+			},
+			cost: 15000,
+		},
+		{
+			name:     "range started on other weekday",
+			tariffs:  []models.PcTypeTariff{mondayNight},
+			start:    at(1, 5, 0),
+			duration: time.Hour,
+			want:     []segment{{at(1, 5, 0), at(1, 6, 0), 0, 10000}},
+			cost:     10000,
+		},
+		{
+			name:     "whole day tariff",
+			tariffs:  []models.PcTypeTariff{weekend},
+			start:    at(5, 23, 0),
+			duration: 2 * time.Hour,
+			want: []segment{
+				{at(5, 23, 0), at(6, 0, 0), 0, 10000},
+				{at(6, 0, 0), at(6, 1, 0), 2, 15000},
+			},
+			cost: 25000,
+		},
+		{
+			name:     "first matching tariff wins",
+			tariffs:  []models.PcTypeTariff{evening, night},
+			start:    at(1, 21, 0),
+			duration: 3 * time.Hour,
+			want: []segment{
+				{at(1, 21, 0), at(1, 23, 0), 3, 40000},
+				{at(1, 23, 0), at(2, 0, 0), 1, 5000},
+			},
+			cost: 45000,
+		},
+		{
+			name:     "start is taken in local time",
+			tariffs:  []models.PcTypeTariff{night},
+			start:    time.Date(2024, time.January, 1, 18, 0, 0, 0, time.UTC),
+			duration: 2 * time.Hour,
+			want: []segment{
+				{at(1, 21, 0), at(1, 22, 0), 0, 10000},
+				{at(1, 22, 0), at(1, 23, 0), 1, 5000},
+			},
+			cost: 15000,
+		},
+		{
+			name:     "part of hour is rounded",
+			start:    at(1, 12, 0),
+			duration: 20 * time.Minute,
+			want:     []segment{{at(1, 12, 0), at(1, 12, 20), 0, 3333}},
+			cost:     3333,
+		},
+		{
+			name:     "zero duration",
+			tariffs:  []models.PcTypeTariff{night},
+			start:    at(1, 12, 0),
+			duration: 0,
+			cost:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Calculate(10000, tt.tariffs, tt.start, tt.duration)
+
+			if got.Cost != tt.cost {
+				t.Errorf("Cost = %s, want %s", got.Cost, tt.cost)
+			}
+			if len(got.Segments) != len(tt.want) {
+				t.Fatalf("got %d segments, want %d: %+v", len(got.Segments), len(tt.want), got.Segments)
+			}
+			for i, want := range tt.want {
+				segment := got.Segments[i]
+				if !segment.Start.Equal(want.start) || !segment.End.Equal(want.end) {
+					t.Errorf("segment %d = [%s, %s), want [%s, %s)", i, segment.Start, segment.End, want.start, want.end)
+				}
+				if segment.Start.Location() != msk {
+					t.Errorf("segment %d start location = %s, want local", i, segment.Start.Location())
+				}
+				if segment.TariffID != want.tariffID {
+					t.Errorf("segment %d tariff = %d, want %d", i, segment.TariffID, want.tariffID)
+				}
+				if segment.Cost != want.cost {
+					t.Errorf("segment %d cost = %s, want %s", i, segment.Cost, want.cost)
+				}
+			}
+		})
+	}
+}
+
+func TestSplit(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	useLocal(t, msk)
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2024, time.January, day, hour, 0, 0, 0, msk)
+	}
+
+	type part struct {
+		start  time.Time
+		end    time.Time
+		inside bool
+	}
+
+	tests := []struct {
+		name        string
+		startMinute int16
+		endMinute   int16
+		start       time.Time
+		end         time.Time
+		want        []part
+	}{
+		{
+			name:        "inside day range",
+			startMinute: 10 * 60,
+			endMinute:   18 * 60,
+			start:       at(1, 12),
+			end:         at(1, 14),
+			want:        []part{{at(1, 12), at(1, 14), true}},
+		},
+		{
+			name:        "crosses day range",
+			startMinute: 10 * 60,
+			endMinute:   18 * 60,
+			start:       at(1, 9),
+			end:         at(1, 19),
+			want: []part{
+				{at(1, 9), at(1, 10), false},
+				{at(1, 10), at(1, 18), true},
+				{at(1, 18), at(1, 19), false},
+			},
+		},
+		{
+			name:        "range passing midnight",
+			startMinute: 22 * 60,
+			endMinute:   6 * 60,
+			start:       at(1, 20),
+			end:         at(2, 8),
+			want: []part{
+				{at(1, 20), at(1, 22), false},
+				{at(1, 22), at(2, 6), true},
+				{at(2, 6), at(2, 8), false},
+			},
+		},
+		{
+			name:        "whole day range",
+			startMinute: 0,
+			endMinute:   0,
+			start:       at(1, 20),
+			end:         at(2, 8),
+			want:        []part{{at(1, 20), at(2, 8), true}},
+		},
+		{
+			name:        "outside range",
+			startMinute: 22 * 60,
+			endMinute:   6 * 60,
+			start:       at(1, 12),
+			end:         at(1, 14),
+			want:        []part{{at(1, 12), at(1, 14), false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(tt.startMinute, tt.endMinute, tt.start, tt.end)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d parts, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if !got[i].Start.Equal(want.start) || !got[i].End.Equal(want.end) || got[i].Inside != want.inside {
+					t.Errorf("part %d = %+v, want %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package tariff
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+)
+
+func (s *Service) DeletePcTypeTariff(
+	ctx context.Context,
+	tariffID int64,
+) error {
+	const op = "services.pcClub.tariff.DeletePcTypeTariff"
+
+	if err := s.owner.DeletePcTypeTariff(ctx, tariffID); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to delete pc type tariff from mssql")
+	}
+
+	return nil
+}
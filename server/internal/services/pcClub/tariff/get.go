@@ -0,0 +1,21 @@
+package tariff
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) PcTypeTariffs(
+	ctx context.Context,
+	typeID int64,
+) ([]models.PcTypeTariff, error) {
+	const op = "services.pcClub.tariff.PcTypeTariffs"
+
+	tariffs, err := s.provider.PcTypeTariffs(ctx, typeID)
+	if err != nil {
+		return nil, errors2.WithMessage(HandleStorageError(err), op, "failed to get pc type tariffs from mssql")
+	}
+
+	return tariffs, nil
+}
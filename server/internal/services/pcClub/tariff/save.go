@@ -0,0 +1,21 @@
+package tariff
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) SavePcTypeTariff(
+	ctx context.Context,
+	tariff *models.PcTypeTariff,
+) (int64, error) {
+	const op = "services.pcClub.tariff.SavePcTypeTariff"
+
+	id, err := s.owner.SavePcTypeTariff(ctx, tariff)
+	if err != nil {
+		return 0, errors2.WithMessage(HandleStorageError(err), op, "failed to save pc type tariff in mssql")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,43 @@
+package tariff
+
+import (
+	"context"
+	"server/internal/models"
+)
+
+type provider interface {
+	PcTypeTariffs(
+		ctx context.Context,
+		typeID int64,
+	) (tariffs []models.PcTypeTariff, err error)
+}
+
+type owner interface {
+	SavePcTypeTariff(
+		ctx context.Context,
+		tariff *models.PcTypeTariff,
+	) (id int64, err error)
+
+	UpdatePcTypeTariff(
+		ctx context.Context,
+		tariffID int64,
+		tariff *models.PcTypeTariff,
+	) (err error)
+
+	DeletePcTypeTariff(
+		ctx context.Context,
+		tariffID int64,
+	) (err error)
+}
+
+type Service struct {
+	provider provider
+	owner    owner
+}
+
+func New(provider provider, owner owner) *Service {
+	return &Service{
+		provider: provider,
+		owner:    owner,
+	}
+}
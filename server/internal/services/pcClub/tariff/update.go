@@ -0,0 +1,21 @@
+package tariff
+
+import (
+	"context"
+	errors2 "server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Service) UpdatePcTypeTariff(
+	ctx context.Context,
+	tariffID int64,
+	tariff *models.PcTypeTariff,
+) error {
+	const op = "services.pcClub.tariff.UpdatePcTypeTariff"
+
+	if err := s.owner.UpdatePcTypeTariff(ctx, tariffID, tariff); err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to update pc type tariff in mssql")
+	}
+
+	return nil
+}
@@ -116,3 +116,22 @@ func (s *Service) IsAdmin(
 
 	return nil
 }
+
+// IsKitchen checks that the user is kitchen staff or admin
+func (s *Service) IsKitchen(
+	ctx context.Context,
+	uid int64,
+) error {
+	const op = "services.pcClub.user.IsKitchen"
+
+	role, err := s.userProvider.UserRole(ctx, uid)
+	if err != nil {
+		return errors2.WithMessage(HandleStorageError(err), op, "failed to get user role from mssql")
+	}
+
+	if role != s.cfg.KitchenRoleName && role != s.cfg.AdminRoleName {
+		return errors2.WithMessage(ErrAccessDenied, op, "user is not kitchen staff")
+	}
+
+	return nil
+}
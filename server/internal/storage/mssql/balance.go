@@ -0,0 +1,251 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+// balanceEntry describes the ledger row written with the balance change
+type balanceEntry struct {
+	kind              string
+	pcOrderID         int64
+	dishOrderID       int64
+	userHourPackageID int64
+	paymentID         int64
+	actorID           int64
+	comment           string
+}
+
+// BalanceTransactions returns ledger of the user balance, newest first
+func (s *Storage) BalanceTransactions(
+	ctx context.Context,
+	uid int64,
+	limit int,
+	offset int,
+) ([]models.BalanceTransaction, error) {
+	const op = "storage.mssql.balance.BalanceTransactions"
+
+	var transactions []models.BalanceTransaction
+	if res := s.db.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("transaction_date DESC, balance_transaction_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get balance transactions")
+	}
+
+	return transactions, nil
+}
+
+// LedgerBalance returns the user balance derived from the ledger
+func (s *Storage) LedgerBalance(
+	ctx context.Context,
+	uid int64,
+) (money.Money, error) {
+	const op = "storage.mssql.balance.LedgerBalance"
+
+	var balance money.Money
+	query := "SELECT COALESCE(SUM(amount), 0) FROM dbo.balance_transactions WHERE user_id = ?"
+	if res := s.db.WithContext(ctx).Raw(query, uid).Scan(&balance); res.Error != nil {
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to sum balance transactions")
+	}
+
+	return balance, nil
+}
+
+// TopUpBalance credits amount to the user balance as a top-up made by actor
+func (s *Storage) TopUpBalance(
+	ctx context.Context,
+	uid int64,
+	actorID int64,
+	amount money.Money,
+	comment string,
+) (models.BalanceTransaction, error) {
+	const op = "storage.mssql.balance.TopUpBalance"
+
+	var transaction models.BalanceTransaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var err error
+		transaction, err = changeBalance(tx, uid, amount, false, balanceEntry{
+			kind:    TopUpBalanceTransaction,
+			actorID: actorID,
+			comment: comment,
+		})
+		return err
+	})
+	if err != nil {
+		return models.BalanceTransaction{}, errors.WithMessage(err, op, "failed to top up balance")
+	}
+
+	return transaction, nil
+}
+
+// AdjustBalance changes the user balance by signed amount on behalf of admin,
+// balance can not become negative
+func (s *Storage) AdjustBalance(
+	ctx context.Context,
+	uid int64,
+	adminID int64,
+	amount money.Money,
+	comment string,
+) (models.BalanceTransaction, error) {
+	const op = "storage.mssql.balance.AdjustBalance"
+
+	var transaction models.BalanceTransaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var err error
+		transaction, err = changeBalance(tx, uid, amount, amount < 0, balanceEntry{
+			kind:    AdjustmentBalanceTransaction,
+			actorID: adminID,
+			comment: comment,
+		})
+		return err
+	})
+	if err != nil {
+		return models.BalanceTransaction{}, errors.WithMessage(err, op, "failed to adjust balance")
+	}
+
+	return transaction, nil
+}
+
+// ReconcileBalance writes an adjustment entry covering the difference between
+// the stored user balance and the ledger sum, the stored balance is kept.
+// Returns zero transaction when the balance already matches the ledger
+func (s *Storage) ReconcileBalance(
+	ctx context.Context,
+	uid int64,
+	adminID int64,
+) (models.BalanceTransaction, error) {
+	const op = "storage.mssql.balance.ReconcileBalance"
+
+	var transaction models.BalanceTransaction
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var user models.User
+		if res := tx.Table(forUpdate(models.TableNameUser)).
+			First(&user, uid); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get user")
+		}
+
+		var ledger money.Money
+		query := "SELECT COALESCE(SUM(amount), 0) FROM dbo.balance_transactions WHERE user_id = ?"
+		if res := tx.Raw(query, uid).Scan(&ledger); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to sum balance transactions")
+		}
+
+		if user.Balance == ledger {
+			return nil
+		}
+
+		transaction = models.BalanceTransaction{
+			UserID:       uid,
+			Kind:         AdjustmentBalanceTransaction,
+			Amount:       user.Balance - ledger,
+			BalanceAfter: user.Balance,
+			ActorID:      adminID,
+			Comment:      "reconciliation",
+		}
+		if res := tx.Omit("PcOrderID", "DishOrderID", "UserHourPackageID", "PaymentID").
+			Create(&transaction); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to create balance transaction")
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return models.BalanceTransaction{}, errors.WithMessage(err, op, "failed to reconcile balance")
+	}
+
+	return transaction, nil
+}
+
+func debitBalance(tx *gorm2.DB, uid int64, amount money.Money, entry balanceEntry) error {
+	_, err := changeBalance(tx, uid, -amount, true, entry)
+	return err
+}
+
+func creditBalance(tx *gorm2.DB, uid int64, amount money.Money, entry balanceEntry) error {
+	if amount == 0 {
+		return nil
+	}
+
+	_, err := changeBalance(tx, uid, amount, false, entry)
+	return err
+}
+
+// changeBalance adds signed amount to the user balance and appends the ledger
+// entry with resulting balance. With checked set the balance must cover amount
+func changeBalance(
+	tx *gorm2.DB,
+	uid int64,
+	amount money.Money,
+	checked bool,
+	entry balanceEntry,
+) (models.BalanceTransaction, error) {
+	query := "UPDATE dbo.users SET balance = balance + ? OUTPUT inserted.balance WHERE user_id = ?"
+	args := []interface{}{amount, uid}
+	if checked {
+		query += " AND balance + ? >= 0"
+		args = append(args, amount)
+	}
+
+	var balances []money.Money
+	if res := tx.Raw(query, args...).Scan(&balances); res.Error != nil {
+		return models.BalanceTransaction{}, errors.WithMessage(errorByResult(res), "failed to change balance")
+	}
+	if len(balances) == 0 {
+		if checked {
+			return models.BalanceTransaction{}, ErrNotEnoughBalance
+		}
+		return models.BalanceTransaction{}, ErrNotFound
+	}
+
+	query = `
+INSERT INTO dbo.balance_transactions
+(user_id, kind, amount, balance_after, pc_order_id, dish_order_id, user_hour_package_id, payment_id, actor_id, comment)
+OUTPUT inserted.balance_transaction_id, inserted.transaction_date
+VALUES (?, ?, ?, ?, NULLIF(?, 0), NULLIF(?, 0), NULLIF(?, 0), NULLIF(?, 0), NULLIF(?, 0), NULLIF(?, ''))`
+
+	var created struct {
+		BalanceTransactionID int64
+		TransactionDate      time.Time
+	}
+	if res := tx.Raw(query,
+		uid,
+		entry.kind,
+		amount,
+		balances[0],
+		entry.pcOrderID,
+		entry.dishOrderID,
+		entry.userHourPackageID,
+		entry.paymentID,
+		entry.actorID,
+		entry.comment,
+	).Scan(&created); res.Error != nil {
+		return models.BalanceTransaction{}, errors.WithMessage(errorByResult(res), "failed to create balance transaction")
+	}
+
+	return models.BalanceTransaction{
+		BalanceTransactionID: created.BalanceTransactionID,
+		UserID:               uid,
+		Kind:                 entry.kind,
+		Amount:               amount,
+		BalanceAfter:         balances[0],
+		PcOrderID:            entry.pcOrderID,
+		DishOrderID:          entry.dishOrderID,
+		UserHourPackageID:    entry.userHourPackageID,
+		PaymentID:            entry.paymentID,
+		ActorID:              entry.actorID,
+		Comment:              entry.comment,
+		TransactionDate:      created.TransactionDate,
+	}, nil
+}
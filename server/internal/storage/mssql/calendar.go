@@ -0,0 +1,81 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+	"time"
+)
+
+// SaveCalendarToken sets calendar token of the user replacing the previous one
+func (s *Storage) SaveCalendarToken(
+	ctx context.Context,
+	uid int64,
+	token string,
+) error {
+	const op = "storage.mssql.calendar.SaveCalendarToken"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.Delete(&models.UserCalendarToken{}, uid); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to delete calendar token")
+		}
+
+		calendarToken := models.UserCalendarToken{
+			UserID: uid,
+			Token:  token,
+		}
+		if res := tx.Create(&calendarToken); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create calendar token")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to save calendar token")
+	}
+
+	return nil
+}
+
+func (s *Storage) CalendarTokenUser(
+	ctx context.Context,
+	token string,
+) (int64, error) {
+	const op = "storage.mssql.calendar.CalendarTokenUser"
+
+	var calendarToken models.UserCalendarToken
+	if res := s.db.WithContext(ctx).
+		Where("token = ?", token).
+		First(&calendarToken); gorm.IsFailResult(res) {
+
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to get calendar token")
+	}
+
+	return calendarToken.UserID, nil
+}
+
+// UpcomingPcOrders returns booked and active orders of the user
+// ending after the time, the earliest first
+func (s *Storage) UpcomingPcOrders(
+	ctx context.Context,
+	uid int64,
+	after time.Time,
+) ([]models.PcOrder, error) {
+	const op = "storage.mssql.calendar.UpcomingPcOrders"
+
+	var orders []models.PcOrder
+	if res := s.db.WithContext(ctx).
+		Preload("PcOrderStatus").
+		Preload("Pc.PcRoom").
+		Joins("JOIN dbo.pc_order_statuses pos ON pos.pc_order_status_id = pc_orders.pc_order_status_id").
+		Where("pc_orders.user_id = ? AND pos.name IN ? AND pc_orders.actual_end_time > ?", uid, busyPcOrderStatuses, after).
+		Order("pc_orders.start_time").
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get upcoming pc orders")
+	}
+
+	return orders, nil
+}
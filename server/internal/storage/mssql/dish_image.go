@@ -0,0 +1,166 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+)
+
+// DishImages returns images of the dish, the main image first
+func (s *Storage) DishImages(
+	ctx context.Context,
+	dishID int64,
+) ([]models.DishImage, error) {
+	const op = "storage.mssql.dish_image.DishImages"
+
+	var dishImages []models.DishImage
+	if res := s.db.WithContext(ctx).
+		Where("dish_id = ?", dishID).
+		Scopes(orderDishImages).
+		Find(&dishImages); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get dish images")
+	}
+
+	return dishImages, nil
+}
+
+// SaveDishImage saves the image of the dish, the first image of the dish
+// becomes main and the main image replaces the previous one
+func (s *Storage) SaveDishImage(
+	ctx context.Context,
+	dishImage *models.DishImage,
+) (int64, error) {
+	const op = "storage.mssql.dish_image.SaveDishImage"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var count int64
+		if res := tx.
+			Table(forUpdate(models.TableNameDishImage)).
+			Where("dish_id = ?", dishImage.DishID).
+			Count(&count); res.Error != nil {
+
+			return errors.WithMessage(errorByResult(res), "failed to count dish images")
+		}
+		if count == 0 {
+			dishImage.IsMain = true
+		}
+
+		if dishImage.IsMain {
+			if err := resetMainDishImage(tx, dishImage.DishID); err != nil {
+				return err
+			}
+		}
+
+		if res := tx.Create(dishImage); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create dish image")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to save dish image")
+	}
+
+	return dishImage.DishImageID, nil
+}
+
+// SetMainDishImage makes the image main for its dish and returns the image
+func (s *Storage) SetMainDishImage(
+	ctx context.Context,
+	imageID int64,
+) (models.DishImage, error) {
+	const op = "storage.mssql.dish_image.SetMainDishImage"
+
+	var dishImage models.DishImage
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Table(forUpdate(models.TableNameDishImage)).
+			First(&dishImage, imageID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get dish image")
+		}
+
+		if err := resetMainDishImage(tx, dishImage.DishID); err != nil {
+			return err
+		}
+
+		if res := tx.
+			Model(&models.DishImage{}).
+			Where("dish_image_id = ?", imageID).
+			Update("is_main", true); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to set main dish image")
+		}
+		dishImage.IsMain = true
+
+		return nil
+	})
+	if err != nil {
+		return models.DishImage{}, errors.WithMessage(err, op, "failed to set main dish image")
+	}
+
+	return dishImage, nil
+}
+
+// DeleteDishImage deletes the image and returns it, when the main image
+// is deleted the oldest remaining image of the dish becomes main
+func (s *Storage) DeleteDishImage(
+	ctx context.Context,
+	imageID int64,
+) (models.DishImage, error) {
+	const op = "storage.mssql.dish_image.DeleteDishImage"
+
+	var dishImage models.DishImage
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Table(forUpdate(models.TableNameDishImage)).
+			First(&dishImage, imageID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get dish image")
+		}
+
+		if res := tx.Delete(&models.DishImage{}, imageID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to delete dish image")
+		}
+
+		if !dishImage.IsMain {
+			return nil
+		}
+
+		query := `
+UPDATE dbo.dish_images
+SET is_main = 1
+WHERE dish_image_id = (SELECT MIN(dish_image_id) FROM dbo.dish_images WHERE dish_id = ?)`
+
+		if res := tx.Exec(query, dishImage.DishID); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to set main dish image")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.DishImage{}, errors.WithMessage(err, op, "failed to delete dish image")
+	}
+
+	return dishImage, nil
+}
+
+// orderDishImages orders images of a dish with the main image first
+func orderDishImages(db *gorm2.DB) *gorm2.DB {
+	return db.Order("is_main DESC, dish_image_id")
+}
+
+func resetMainDishImage(tx *gorm2.DB, dishID int64) error {
+	if res := tx.
+		Model(&models.DishImage{}).
+		Where("dish_id = ? AND is_main = ?", dishID, true).
+		Update("is_main", false); res.Error != nil {
+
+		return errors.WithMessage(errorByResult(res), "failed to reset main dish image")
+	}
+
+	return nil
+}
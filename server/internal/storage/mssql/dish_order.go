@@ -0,0 +1,364 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+func (s *Storage) DishOrders(
+	ctx context.Context,
+	uid int64,
+) ([]models.DishOrder, error) {
+	const op = "storage.mssql.dish_order.DishOrders"
+
+	var orders []models.DishOrder
+	if res := s.db.WithContext(ctx).
+		Preload("DishOrderStatus").
+		Preload("DishOrderList.Dish").
+		Preload("PcOrder.Pc.PcRoom").
+		Where("user_id = ?", uid).
+		Order("order_date DESC").
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get dish orders")
+	}
+
+	return orders, nil
+}
+
+// DishOrder returns the order with its status and list
+func (s *Storage) DishOrder(
+	ctx context.Context,
+	orderID int64,
+) (models.DishOrder, error) {
+	const op = "storage.mssql.dish_order.DishOrder"
+
+	var order models.DishOrder
+	if res := s.db.WithContext(ctx).
+		Preload("DishOrderStatus").
+		Preload("DishOrderList.Dish").
+		Preload("PcOrder.Pc.PcRoom").
+		First(&order, orderID); gorm.IsFailResult(res) {
+
+		return models.DishOrder{}, errors.WithMessage(errorByResult(res), op, "failed to get dish order")
+	}
+
+	return order, nil
+}
+
+// OpenDishOrders returns orders which are not delivered or cancelled yet, the oldest first
+func (s *Storage) OpenDishOrders(
+	ctx context.Context,
+) ([]models.DishOrder, error) {
+	const op = "storage.mssql.dish_order.OpenDishOrders"
+
+	var orders []models.DishOrder
+	if res := s.db.WithContext(ctx).
+		Preload("DishOrderStatus").
+		Preload("DishOrderList.Dish").
+		Preload("PcOrder.Pc.PcRoom").
+		Joins("JOIN dbo.dish_order_statuses dos ON dos.dish_order_status_id = dish_orders.dish_order_status_id").
+		Where("dos.name IN ?", openDishOrderStatuses).
+		Order("dish_orders.order_date, dish_orders.dish_order_id").
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get open dish orders")
+	}
+
+	return orders, nil
+}
+
+// ChangeDishOrderStatus moves the order from status to status on behalf of actor
+// and credits refund to the order owner uid balance. Dishes of cancelled order
+// are put back to stock and its promotion redemption is revoked
+func (s *Storage) ChangeDishOrderStatus(
+	ctx context.Context,
+	orderID int64,
+	uid int64,
+	from string,
+	to string,
+	actorID int64,
+	refund money.Money,
+) error {
+	const op = "storage.mssql.dish_order.ChangeDishOrderStatus"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if err := changeDishOrderStatus(tx, orderID, from, to, actorID); err != nil {
+			return err
+		}
+
+		if to == CancelledDishOrderStatus {
+			if err := returnDishStock(tx, orderID); err != nil {
+				return err
+			}
+			if err := revokePromotionRedemption(tx, "dish_order_id", orderID); err != nil {
+				return err
+			}
+		}
+
+		if refund == 0 {
+			return nil
+		}
+
+		return creditBalance(tx, uid, refund, balanceEntry{
+			kind:        RefundBalanceTransaction,
+			dishOrderID: orderID,
+			actorID:     actorID,
+			comment:     "dish order " + to,
+		})
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to change dish order status")
+	}
+
+	return nil
+}
+
+// DishesByIDs returns dishes with their statuses, missing ids are skipped
+func (s *Storage) DishesByIDs(
+	ctx context.Context,
+	dishIDs []int64,
+) ([]models.Dish, error) {
+	const op = "storage.mssql.dish_order.DishesByIDs"
+
+	var dishes []models.Dish
+	if res := s.db.WithContext(ctx).
+		Preload("DishStatus").
+		Where("dish_id IN ?", dishIDs).
+		Find(&dishes); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get dishes")
+	}
+
+	return dishes, nil
+}
+
+// SaveDishOrder inserts accepted order with its list debiting its cost from the
+// user balance. All dishes are checked to be orderable under lock. Promotion is
+// redeemed for the order when redemption is not nil
+func (s *Storage) SaveDishOrder(
+	ctx context.Context,
+	order *models.DishOrder,
+	redemption *models.PromotionRedemption,
+) (int64, error) {
+	const op = "storage.mssql.dish_order.SaveDishOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		dishIDs := make([]int64, 0, len(order.DishOrderList))
+		for _, item := range order.DishOrderList {
+			dishIDs = append(dishIDs, item.DishID)
+		}
+		if err := checkDishesOrderable(tx, dishIDs); err != nil {
+			return err
+		}
+
+		if err := takeDishStock(tx, order.DishOrderList); err != nil {
+			return err
+		}
+
+		statusID, err := dishOrderStatusID(tx, AcceptedDishOrderStatus)
+		if err != nil {
+			return err
+		}
+		order.DishOrderStatusID = statusID
+
+		pcOrderID, err := activePcOrderID(tx, order.UserID)
+		if err != nil {
+			return err
+		}
+		order.PcOrderID = pcOrderID
+
+		create := tx
+		if order.PcOrderID == 0 {
+			create = tx.Omit("PcOrderID")
+		}
+		if res := create.Create(order); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create dish order")
+		}
+
+		if err := debitBalance(tx, order.UserID, order.Cost, balanceEntry{
+			kind:        DishChargeBalanceTransaction,
+			dishOrderID: order.DishOrderID,
+			actorID:     order.UserID,
+		}); err != nil {
+			return err
+		}
+
+		if err := recordDishOrderStatus(tx, []int64{order.DishOrderID}, "", AcceptedDishOrderStatus, order.UserID); err != nil {
+			return err
+		}
+
+		if redemption != nil {
+			redemption.UserID = order.UserID
+			redemption.DishOrderID = order.DishOrderID
+			if err := redeemPromotion(tx, redemption); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to save dish order")
+	}
+
+	return order.DishOrderID, nil
+}
+
+// DishOrderHistory returns up to limit orders of the user with order date in [from, to)
+// ordered by order date and id descending. Only orders before the (before, beforeID)
+// key are returned when before is not zero, status and range bounds are not
+// filtered when they are empty
+func (s *Storage) DishOrderHistory(
+	ctx context.Context,
+	uid int64,
+	status string,
+	from time.Time,
+	to time.Time,
+	before time.Time,
+	beforeID int64,
+	limit int,
+) ([]models.DishOrder, error) {
+	const op = "storage.mssql.dish_order.DishOrderHistory"
+
+	query := s.db.WithContext(ctx).
+		Preload("DishOrderStatus").
+		Preload("DishOrderList.Dish").
+		Where("dish_orders.user_id = ?", uid)
+	if status != "" {
+		query = query.
+			Joins("JOIN dbo.dish_order_statuses dos ON dos.dish_order_status_id = dish_orders.dish_order_status_id").
+			Where("dos.name = ?", status)
+	}
+	if !from.IsZero() {
+		query = query.Where("dish_orders.order_date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("dish_orders.order_date < ?", to)
+	}
+	if !before.IsZero() {
+		query = query.Where(
+			"dish_orders.order_date < ? OR (dish_orders.order_date = ? AND dish_orders.dish_order_id < ?)",
+			before, before, beforeID,
+		)
+	}
+
+	var orders []models.DishOrder
+	if res := query.
+		Order("dish_orders.order_date DESC, dish_orders.dish_order_id DESC").
+		Limit(limit).
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get dish order history")
+	}
+
+	return orders, nil
+}
+
+// checkDishesOrderable returns ErrCheckFailed when any of the dishes does
+// not exist or is not orderable, dish rows stay locked until the end of transaction
+func checkDishesOrderable(tx *gorm2.DB, dishIDs []int64) error {
+	query := `
+SELECT COUNT(*)
+FROM dbo.dishes WITH (UPDLOCK, ROWLOCK)
+JOIN dbo.dish_statuses ON dish_statuses.dish_status_id = dishes.dish_status_id
+WHERE dishes.dish_id IN ?
+  AND dish_statuses.name IN ?`
+
+	var count int64
+	if res := tx.Raw(query, dishIDs, OrderableDishStatuses).Scan(&count); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to check dishes")
+	}
+	if count != int64(len(dishIDs)) {
+		return errors.WithMessage(ErrCheckFailed, "dish is not orderable")
+	}
+
+	return nil
+}
+
+// activePcOrderID returns id of the pc order the user is checked in to
+// or zero when the user has no active session
+func activePcOrderID(tx *gorm2.DB, uid int64) (int64, error) {
+	query := `
+SELECT TOP 1 pc_orders.pc_order_id
+FROM dbo.pc_orders
+JOIN dbo.pc_order_statuses ON pc_order_statuses.pc_order_status_id = pc_orders.pc_order_status_id
+WHERE pc_orders.user_id = ?
+  AND pc_order_statuses.name = ?
+ORDER BY pc_orders.start_time DESC`
+
+	var ids []int64
+	if res := tx.Raw(query, uid, ActivePcOrderStatus).Scan(&ids); res.Error != nil {
+		return 0, errors.WithMessage(errorByResult(res), "failed to get active pc order")
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return ids[0], nil
+}
+
+// changeDishOrderStatus moves the order from status to status on behalf of actor,
+// it returns ErrCheckFailed when the transition is not allowed
+// or the order is not in from status
+func changeDishOrderStatus(tx *gorm2.DB, orderID int64, from string, to string, actorID int64) error {
+	if err := CheckDishOrderTransition(from, to); err != nil {
+		return err
+	}
+
+	query := `
+UPDATE dbo.dish_orders
+SET dish_order_status_id = (SELECT dish_order_status_id FROM dbo.dish_order_statuses WHERE name = ?)
+WHERE dish_order_id = ?
+  AND dish_order_status_id = (SELECT dish_order_status_id FROM dbo.dish_order_statuses WHERE name = ?)`
+
+	res := tx.Exec(query, to, orderID, from)
+	if res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to change dish order status")
+	}
+	if res.RowsAffected == 0 {
+		return errors.WithMessage(ErrCheckFailed, "dish order is not "+from)
+	}
+
+	return recordDishOrderStatus(tx, []int64{orderID}, from, to, actorID)
+}
+
+func dishOrderStatusID(tx *gorm2.DB, name string) (int64, error) {
+	var statusID int64
+	if res := tx.
+		Model(&models.DishOrderStatus{}).
+		Select("dish_order_status_id").
+		Where("name = ?", name).
+		First(&statusID); gorm.IsFailResult(res) {
+
+		return 0, errors.WithMessage(errorByResult(res), "failed to get dish order status "+name)
+	}
+
+	return statusID, nil
+}
+
+// recordDishOrderStatus writes status changes of orders in history,
+// empty from means the order creation and zero actor means the system
+func recordDishOrderStatus(tx *gorm2.DB, orderIDs []int64, from string, to string, actorID int64) error {
+	query := `
+INSERT INTO dbo.dish_order_status_history (dish_order_id, from_dish_order_status_id, to_dish_order_status_id, user_id)
+SELECT
+    dish_orders.dish_order_id,
+    (SELECT dish_order_status_id FROM dbo.dish_order_statuses WHERE name = ?),
+    (SELECT dish_order_status_id FROM dbo.dish_order_statuses WHERE name = ?),
+    NULLIF(?, 0)
+FROM dbo.dish_orders
+WHERE dish_orders.dish_order_id IN ?`
+
+	if res := tx.Exec(query, from, to, actorID, orderIDs); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to record dish order status")
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+)
+
+// DishStocks returns stocks of dishes with tracked inventory
+func (s *Storage) DishStocks(
+	ctx context.Context,
+) ([]models.DishStock, error) {
+	const op = "storage.mssql.dish_stock.DishStocks"
+
+	var stocks []models.DishStock
+	if res := s.db.WithContext(ctx).
+		Preload("Dish.DishStatus").
+		Order("dish_id").
+		Find(&stocks); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get dish stocks")
+	}
+
+	return stocks, nil
+}
+
+// SaveDishStock starts tracking stock of the dish or sets its count
+// and switches the dish between available and out of stock by the count
+func (s *Storage) SaveDishStock(
+	ctx context.Context,
+	dishID int64,
+	count int,
+) error {
+	const op = "storage.mssql.dish_stock.SaveDishStock"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		query := `
+MERGE dbo.dish_stocks WITH (HOLDLOCK) AS target
+USING (SELECT ? AS dish_id, ? AS count) AS source
+ON target.dish_id = source.dish_id
+WHEN MATCHED THEN UPDATE SET count = source.count
+WHEN NOT MATCHED THEN INSERT (dish_id, count) VALUES (source.dish_id, source.count);`
+
+		if res := tx.Exec(query, dishID, count); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to save dish stock")
+		}
+
+		return syncDishStatuses(tx, []int64{dishID})
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to save dish stock")
+	}
+
+	return nil
+}
+
+// RestockDish adds count to the tracked stock of the dish,
+// the dish out of stock becomes available again
+func (s *Storage) RestockDish(
+	ctx context.Context,
+	dishID int64,
+	count int,
+) error {
+	const op = "storage.mssql.dish_stock.RestockDish"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Model(&models.DishStock{}).
+			Where("dish_id = ?", dishID).
+			Update("count", gorm2.Expr("count + ?", count)); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to restock dish")
+		}
+
+		return syncDishStatuses(tx, []int64{dishID})
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to restock dish")
+	}
+
+	return nil
+}
+
+// DeleteDishStock stops tracking stock of the dish,
+// the dish out of stock becomes available again
+func (s *Storage) DeleteDishStock(
+	ctx context.Context,
+	dishID int64,
+) error {
+	const op = "storage.mssql.dish_stock.DeleteDishStock"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.Delete(&models.DishStock{}, dishID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to delete dish stock")
+		}
+
+		availableID, err := dishStatusID(tx, AvailableDishStatus)
+		if err != nil {
+			return err
+		}
+		outOfStockID, err := dishStatusID(tx, OutOfStockDishStatus)
+		if err != nil {
+			return err
+		}
+
+		if res := tx.
+			Model(&models.Dish{}).
+			Where("dish_id = ? AND dish_status_id = ?", dishID, outOfStockID).
+			Update("dish_status_id", availableID); res.Error != nil {
+
+			return errors.WithMessage(errorByResult(res), "failed to update dish status")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to delete dish stock")
+	}
+
+	return nil
+}
+
+// takeDishStock decrements tracked stock of the ordered dishes, it returns
+// ErrOutOfStock when there is not enough of any dish. Dishes without
+// tracked stock are not limited
+func takeDishStock(tx *gorm2.DB, items []models.DishOrderList) error {
+	query := `
+UPDATE dbo.dish_stocks
+SET count = count - ?
+WHERE dish_id = ?
+  AND count >= ?`
+
+	dishIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		res := tx.Exec(query, item.Count, item.DishID, item.Count)
+		if res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to take dish stock")
+		}
+		if res.RowsAffected != 0 {
+			dishIDs = append(dishIDs, item.DishID)
+			continue
+		}
+
+		var tracked int64
+		if res := tx.
+			Model(&models.DishStock{}).
+			Where("dish_id = ?", item.DishID).
+			Count(&tracked); res.Error != nil {
+
+			return errors.WithMessage(errorByResult(res), "failed to check dish stock")
+		}
+		if tracked != 0 {
+			return errors.WithMessage(ErrOutOfStock, "dish is out of stock")
+		}
+	}
+
+	if len(dishIDs) == 0 {
+		return nil
+	}
+
+	return syncDishStatuses(tx, dishIDs)
+}
+
+// returnDishStock puts dishes of the order back to their tracked stock
+func returnDishStock(tx *gorm2.DB, orderID int64) error {
+	query := `
+UPDATE dbo.dish_stocks
+SET count = dish_stocks.count + dish_order_list.count
+FROM dbo.dish_stocks
+JOIN dbo.dish_order_list ON dish_order_list.dish_id = dish_stocks.dish_id
+WHERE dish_order_list.dish_order_id = ?`
+
+	if res := tx.Exec(query, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to return dish stock")
+	}
+
+	var dishIDs []int64
+	if res := tx.
+		Model(&models.DishOrderList{}).
+		Where("dish_order_id = ?", orderID).
+		Pluck("dish_id", &dishIDs); res.Error != nil {
+
+		return errors.WithMessage(errorByResult(res), "failed to get dish order list")
+	}
+
+	return syncDishStatuses(tx, dishIDs)
+}
+
+// syncDishStatuses switches available dishes with empty tracked stock
+// to out of stock and back when they are restocked. Dishes in other
+// statuses are left as is
+func syncDishStatuses(tx *gorm2.DB, dishIDs []int64) error {
+	availableID, err := dishStatusID(tx, AvailableDishStatus)
+	if err != nil {
+		return err
+	}
+	outOfStockID, err := dishStatusID(tx, OutOfStockDishStatus)
+	if err != nil {
+		return err
+	}
+
+	query := `
+UPDATE dbo.dishes
+SET dish_status_id = CASE WHEN dish_stocks.count > 0 THEN ? ELSE ? END
+FROM dbo.dishes
+JOIN dbo.dish_stocks ON dish_stocks.dish_id = dishes.dish_id
+WHERE dishes.dish_id IN ?
+  AND dishes.dish_status_id IN (?, ?)`
+
+	if res := tx.Exec(query, availableID, outOfStockID, dishIDs, availableID, outOfStockID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to update dish statuses")
+	}
+
+	return nil
+}
+
+func dishStatusID(tx *gorm2.DB, name string) (int64, error) {
+	var statusID int64
+	if res := tx.
+		Model(&models.DishStatus{}).
+		Select("dish_status_id").
+		Where("name = ?", name).
+		First(&statusID); gorm.IsFailResult(res) {
+
+		return 0, errors.WithMessage(errorByResult(res), "failed to get dish status "+name)
+	}
+
+	return statusID, nil
+}
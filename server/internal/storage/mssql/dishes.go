@@ -16,6 +16,7 @@ func (s *Storage) Dishes(
 
 	var dishes []models.Dish
 	if res := s.db.WithContext(ctx).
+		Preload("DishImages", orderDishImages).
 		Limit(limit).
 		Offset(offset).
 		Find(&dishes); gorm.IsFailResult(res) {
@@ -33,7 +34,10 @@ func (s *Storage) Dish(
 	const op = "storage.mssql.dish.Dish"
 
 	var dish models.Dish
-	if res := s.db.WithContext(ctx).First(&dish, dishID); gorm.IsFailResult(res) {
+	if res := s.db.WithContext(ctx).
+		Preload("DishImages", orderDishImages).
+		First(&dish, dishID); gorm.IsFailResult(res) {
+
 		return models.Dish{}, errors.WithMessage(errorByResult(res), op, "failed to get dish")
 	}
 
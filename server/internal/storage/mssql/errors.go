@@ -20,6 +20,8 @@ const (
 	ErrAlreadyExistsCode      = "AlreadyExists"
 	ErrReferenceNotExistsCode = "ReferenceNotExists"
 	ErrCheckFailedCode        = "CheckFailed"
+	ErrNotEnoughBalanceCode   = "NotEnoughBalance"
+	ErrOutOfStockCode         = "OutOfStock"
 )
 
 var (
@@ -35,6 +37,18 @@ var (
 		Code:    ErrReferenceNotExistsCode,
 		Message: "reference not exists",
 	}
+	ErrCheckFailed = &Error{
+		Code:    ErrCheckFailedCode,
+		Message: "check failed",
+	}
+	ErrNotEnoughBalance = &Error{
+		Code:    ErrNotEnoughBalanceCode,
+		Message: "not enough balance",
+	}
+	ErrOutOfStock = &Error{
+		Code:    ErrOutOfStockCode,
+		Message: "out of stock",
+	}
 )
 
 func errorByResult(res *gorm.DB) error {
@@ -0,0 +1,197 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+)
+
+func (s *Storage) HourPackages(
+	ctx context.Context,
+	typeID int64,
+) ([]models.HourPackage, error) {
+	const op = "storage.mssql.hour_package.HourPackages"
+
+	query := s.db.WithContext(ctx)
+	if typeID != 0 {
+		query = query.Where("pc_type_id = ?", typeID)
+	}
+
+	var packages []models.HourPackage
+	if res := query.Order("hour_package_id").Find(&packages); res.Error != nil {
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get hour packages")
+	}
+
+	return packages, nil
+}
+
+func (s *Storage) SaveHourPackage(
+	ctx context.Context,
+	hourPackage *models.HourPackage,
+) (int64, error) {
+	const op = "storage.mssql.hour_package.SaveHourPackage"
+
+	if res := s.db.WithContext(ctx).Save(hourPackage); gorm.IsFailResult(res) {
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to save hour package")
+	}
+
+	return hourPackage.HourPackageID, nil
+}
+
+func (s *Storage) UpdateHourPackage(
+	ctx context.Context,
+	packageID int64,
+	hourPackage *models.HourPackage,
+) error {
+	const op = "storage.mssql.hour_package.UpdateHourPackage"
+
+	if res := s.db.WithContext(ctx).
+		Where("hour_package_id = ?", packageID).
+		Select("pc_type_id", "name", "description", "cost", "minutes", "start_minute", "end_minute").
+		Updates(hourPackage); gorm.IsFailResult(res) {
+
+		return errors.WithMessage(errorByResult(res), op, "failed to update hour package")
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteHourPackage(
+	ctx context.Context,
+	packageID int64,
+) error {
+	const op = "storage.mssql.hour_package.DeleteHourPackage"
+
+	if res := s.db.WithContext(ctx).Delete(&models.HourPackage{}, packageID); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), op, "failed to delete hour package")
+	}
+
+	return nil
+}
+
+// UserHourPackages returns packages of the user with minutes left, oldest first.
+// Empty wallet is not an error as it is used on every booking
+func (s *Storage) UserHourPackages(
+	ctx context.Context,
+	uid int64,
+) ([]models.UserHourPackage, error) {
+	const op = "storage.mssql.hour_package.UserHourPackages"
+
+	var packages []models.UserHourPackage
+	if res := s.db.WithContext(ctx).
+		Preload("HourPackage").
+		Where("user_id = ? AND minutes > 0", uid).
+		Order("purchase_date, user_hour_package_id").
+		Find(&packages); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get user hour packages")
+	}
+
+	return packages, nil
+}
+
+// BuyHourPackage debits package cost from the user balance
+// and puts all package minutes to the user wallet
+func (s *Storage) BuyHourPackage(
+	ctx context.Context,
+	uid int64,
+	packageID int64,
+) (int64, error) {
+	const op = "storage.mssql.hour_package.BuyHourPackage"
+
+	var userPackage models.UserHourPackage
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var hourPackage models.HourPackage
+		if res := tx.First(&hourPackage, packageID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to get hour package")
+		}
+
+		userPackage = models.UserHourPackage{
+			UserID:        uid,
+			HourPackageID: packageID,
+			Minutes:       hourPackage.Minutes,
+		}
+		if res := tx.Create(&userPackage); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create user hour package")
+		}
+
+		return debitBalance(tx, uid, hourPackage.Cost, balanceEntry{
+			kind:              PackageChargeBalanceTransaction,
+			userHourPackageID: userPackage.UserHourPackageID,
+			actorID:           uid,
+			comment:           hourPackage.Name,
+		})
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to buy hour package")
+	}
+
+	return userPackage.UserHourPackageID, nil
+}
+
+// useHourPackages takes minutes from the user wallet for the order
+func useHourPackages(tx *gorm2.DB, uid int64, orderID int64, usages []models.PcOrderHourPackage) error {
+	query := "UPDATE dbo.user_hour_packages SET minutes = minutes - ? " +
+		"WHERE user_hour_package_id = ? AND user_id = ? AND minutes >= ?"
+	for i := range usages {
+		usage := &usages[i]
+
+		res := tx.Exec(query, usage.Minutes, usage.UserHourPackageID, uid, usage.Minutes)
+		if res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to use hour package")
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotEnoughBalance
+		}
+
+		usage.PcOrderID = orderID
+	}
+
+	if len(usages) == 0 {
+		return nil
+	}
+	if res := tx.Create(&usages); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to create pc order hour packages")
+	}
+
+	return nil
+}
+
+// returnHourPackages puts minutes used by the order back to the user wallet
+func returnHourPackages(tx *gorm2.DB, orderID int64) error {
+	query := "UPDATE uhp SET uhp.minutes = uhp.minutes + ophp.minutes " +
+		"FROM dbo.user_hour_packages uhp " +
+		"JOIN dbo.pc_order_hour_packages ophp ON ophp.user_hour_package_id = uhp.user_hour_package_id " +
+		"WHERE ophp.pc_order_id = ?"
+	if res := tx.Exec(query, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to return hour packages")
+	}
+
+	return nil
+}
+
+// returnUnusedHourPackages puts unused/duration share of minutes used by
+// the order back to the user wallet, returned minutes are rounded down
+// and are no longer counted as used by the order
+func returnUnusedHourPackages(tx *gorm2.DB, orderID int64, unused int16, duration int16) error {
+	if unused <= 0 || duration <= 0 {
+		return nil
+	}
+
+	query := "UPDATE uhp SET uhp.minutes = uhp.minutes + ophp.minutes * ? / ? " +
+		"FROM dbo.user_hour_packages uhp " +
+		"JOIN dbo.pc_order_hour_packages ophp ON ophp.user_hour_package_id = uhp.user_hour_package_id " +
+		"WHERE ophp.pc_order_id = ?"
+	if res := tx.Exec(query, unused, duration, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to return hour packages")
+	}
+
+	query = "UPDATE dbo.pc_order_hour_packages SET minutes = minutes - minutes * ? / ? WHERE pc_order_id = ?"
+	if res := tx.Exec(query, unused, duration, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to update pc order hour packages")
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+)
+
+// Payments returns top-up payments of the user, newest first
+func (s *Storage) Payments(
+	ctx context.Context,
+	uid int64,
+	limit int,
+	offset int,
+) ([]models.Payment, error) {
+	const op = "storage.mssql.payment.Payments"
+
+	var payments []models.Payment
+	if res := s.db.WithContext(ctx).
+		Where("user_id = ?", uid).
+		Order("created_date DESC, payment_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&payments); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get payments")
+	}
+
+	return payments, nil
+}
+
+func (s *Storage) Payment(
+	ctx context.Context,
+	paymentID int64,
+) (models.Payment, error) {
+	const op = "storage.mssql.payment.Payment"
+
+	var payment models.Payment
+	if res := s.db.WithContext(ctx).First(&payment, paymentID); gorm.IsFailResult(res) {
+		return models.Payment{}, errors.WithMessage(errorByResult(res), op, "failed to get payment")
+	}
+
+	return payment, nil
+}
+
+// SavePayment creates pending payment of the user,
+// it gets external id after it is registered at the provider
+func (s *Storage) SavePayment(
+	ctx context.Context,
+	payment *models.Payment,
+) (int64, error) {
+	const op = "storage.mssql.payment.SavePayment"
+
+	payment.Status = PendingPaymentStatus
+	if res := s.db.WithContext(ctx).
+		Omit("ExternalID", "RedirectURL", "EventID", "CreatedDate", "UpdatedDate").
+		Create(payment); gorm.IsFailResult(res) {
+
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to create payment")
+	}
+
+	return payment.PaymentID, nil
+}
+
+// RegisterPayment sets provider id and checkout url of the pending payment
+func (s *Storage) RegisterPayment(
+	ctx context.Context,
+	paymentID int64,
+	externalID string,
+	redirectURL string,
+) error {
+	const op = "storage.mssql.payment.RegisterPayment"
+
+	if res := s.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("payment_id = ? AND status = ?", paymentID, PendingPaymentStatus).
+		Updates(map[string]interface{}{
+			"external_id":  externalID,
+			"redirect_url": redirectURL,
+			"updated_date": gorm2.Expr("getdate()"),
+		}); gorm.IsFailResult(res) {
+
+		return errors.WithMessage(errorByResult(res), op, "failed to register payment")
+	}
+
+	return nil
+}
+
+// FailPayment marks pending payment failed, it is used when
+// the provider refuses to create the payment
+func (s *Storage) FailPayment(
+	ctx context.Context,
+	paymentID int64,
+) error {
+	const op = "storage.mssql.payment.FailPayment"
+
+	if res := s.db.WithContext(ctx).
+		Model(&models.Payment{}).
+		Where("payment_id = ? AND status = ?", paymentID, PendingPaymentStatus).
+		Updates(map[string]interface{}{
+			"status":       FailedPaymentStatus,
+			"updated_date": gorm2.Expr("getdate()"),
+		}); gorm.IsFailResult(res) {
+
+		return errors.WithMessage(errorByResult(res), op, "failed to fail payment")
+	}
+
+	return nil
+}
+
+// CompletePayment applies result reported by the provider webhook to the
+// pending payment and credits the user balance when it succeeded. Events
+// which were already applied and results for already completed payments
+// are ignored, so repeated deliveries are safe. The payment is found by our
+// id, external id is saved when registering it at the provider failed.
+// Applied reports whether the payment was changed
+func (s *Storage) CompletePayment(
+	ctx context.Context,
+	provider string,
+	paymentID int64,
+	externalID string,
+	eventID string,
+	status string,
+	amount money.Money,
+) (payment models.Payment, applied bool, err error) {
+	const op = "storage.mssql.payment.CompletePayment"
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var seen int64
+		query := "SELECT COUNT(*) FROM dbo.payments WITH (UPDLOCK, HOLDLOCK) WHERE provider = ? AND event_id = ?"
+		if res := tx.Raw(query, provider, eventID).Scan(&seen); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to check payment event")
+		}
+
+		if res := tx.Table(forUpdate(models.TableNamePayment)).
+			Where("payment_id = ? AND provider = ?", paymentID, provider).
+			First(&payment); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get payment")
+		}
+
+		if payment.ExternalID != "" && payment.ExternalID != externalID {
+			return errors.WithMessage(ErrCheckFailed, "external id differs from payment external id")
+		}
+		if seen > 0 || payment.Status != PendingPaymentStatus {
+			return nil
+		}
+		if status == SucceededPaymentStatus && payment.Amount != amount {
+			return errors.WithMessage(ErrCheckFailed, "paid amount differs from payment amount")
+		}
+
+		if res := tx.Model(&models.Payment{}).
+			Where("payment_id = ?", payment.PaymentID).
+			Updates(map[string]interface{}{
+				"status":       status,
+				"external_id":  externalID,
+				"event_id":     eventID,
+				"updated_date": gorm2.Expr("getdate()"),
+			}); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to update payment status")
+		}
+		payment.Status = status
+		payment.ExternalID = externalID
+		payment.EventID = eventID
+		applied = true
+
+		if status != SucceededPaymentStatus {
+			return nil
+		}
+
+		return creditBalance(tx, payment.UserID, payment.Amount, balanceEntry{
+			kind:      TopUpBalanceTransaction,
+			paymentID: payment.PaymentID,
+			actorID:   payment.UserID,
+			comment:   payment.Provider,
+		})
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return models.Payment{}, false, errors.WithMessage(err, op, "failed to complete payment")
+	}
+
+	return payment, applied, nil
+}
+
+// RefundPayment debits amount of the succeeded payment from the user balance
+// and marks it refunded. The refund callback returns money at the provider,
+// its error rolls the refund back, so balance and provider stay in sync
+func (s *Storage) RefundPayment(
+	ctx context.Context,
+	paymentID int64,
+	adminID int64,
+	refund func(payment models.Payment) error,
+) (models.Payment, error) {
+	const op = "storage.mssql.payment.RefundPayment"
+
+	var payment models.Payment
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.Table(forUpdate(models.TableNamePayment)).
+			First(&payment, paymentID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get payment")
+		}
+
+		if payment.Status != SucceededPaymentStatus {
+			return errors.WithMessage(ErrCheckFailed, "only succeeded payment can be refunded")
+		}
+
+		if err := debitBalance(tx, payment.UserID, payment.Amount, balanceEntry{
+			kind:      PaymentRefundBalanceTransaction,
+			paymentID: payment.PaymentID,
+			actorID:   adminID,
+			comment:   payment.Provider,
+		}); err != nil {
+			return err
+		}
+
+		if res := tx.Model(&models.Payment{}).
+			Where("payment_id = ?", payment.PaymentID).
+			Updates(map[string]interface{}{
+				"status":       RefundedPaymentStatus,
+				"updated_date": gorm2.Expr("getdate()"),
+			}); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to update payment status")
+		}
+		payment.Status = RefundedPaymentStatus
+
+		return refund(payment)
+	})
+	if err != nil {
+		return models.Payment{}, errors.WithMessage(err, op, "failed to refund payment")
+	}
+
+	return payment, nil
+}
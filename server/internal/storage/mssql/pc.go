@@ -2,9 +2,11 @@ package mssql
 
 import (
 	"context"
+	gorm2 "gorm.io/gorm"
 	"server/internal/lib/api/database/gorm"
 	"server/internal/lib/errors"
 	"server/internal/models"
+	"time"
 )
 
 func (s *Storage) Pcs(
@@ -26,6 +28,63 @@ func (s *Storage) Pcs(
 	return pcs, nil
 }
 
+func (s *Storage) Pc(
+	ctx context.Context,
+	pcID int64,
+) (models.Pc, error) {
+	const op = "storage.mssql.pc.Pc"
+
+	var pc models.Pc
+	if res := s.db.WithContext(ctx).
+		Preload("PcType").
+		Preload("PcType.PcTypeTariffs", func(db *gorm2.DB) *gorm2.DB {
+			return db.Order("pc_type_tariff_id")
+		}).
+		First(&pc, pcID); gorm.IsFailResult(res) {
+
+		return models.Pc{}, errors.WithMessage(errorByResult(res), op, "failed to get pc")
+	}
+
+	return pc, nil
+}
+
+// PcsWithOrders returns pcs of the type or the room (zero id is not filtered)
+// with preloaded busy orders intersecting [from, to)
+func (s *Storage) PcsWithOrders(
+	ctx context.Context,
+	typeID int64,
+	roomID int64,
+	from time.Time,
+	to time.Time,
+) ([]models.Pc, error) {
+	const op = "storage.mssql.pc.PcsWithOrders"
+
+	db := s.db.WithContext(ctx)
+	if typeID != 0 {
+		db = db.Where("pc_type_id = ?", typeID)
+	}
+	if roomID != 0 {
+		db = db.Where("pc_room_id = ?", roomID)
+	}
+
+	var pcs []models.Pc
+	if res := db.
+		Preload("PcOrders", func(db *gorm2.DB) *gorm2.DB {
+			return db.
+				Joins("PcOrderStatus").
+				Where("PcOrderStatus.name IN ?", busyPcOrderStatuses).
+				Where("start_time < ? AND actual_end_time > ?", to, from).
+				Order("start_time")
+		}).
+		Order("pc_room_id, row, place").
+		Find(&pcs); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pcs with orders")
+	}
+
+	return pcs, nil
+}
+
 func (s *Storage) SavePc(
 	ctx context.Context,
 	pc *models.Pc,
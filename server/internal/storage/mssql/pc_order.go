@@ -0,0 +1,557 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+func (s *Storage) PcOrders(
+	ctx context.Context,
+	uid int64,
+) ([]models.PcOrder, error) {
+	const op = "storage.mssql.pc_order.PcOrders"
+
+	var orders []models.PcOrder
+	if res := s.db.WithContext(ctx).
+		Preload("PcOrderStatus").
+		Where("user_id = ?", uid).
+		Order("start_time DESC").
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pc orders")
+	}
+
+	return orders, nil
+}
+
+func (s *Storage) PcOrder(
+	ctx context.Context,
+	orderID int64,
+) (models.PcOrder, error) {
+	const op = "storage.mssql.pc_order.PcOrder"
+
+	var order models.PcOrder
+	if res := s.db.WithContext(ctx).
+		Preload("PcOrderStatus").
+		Preload("Pc.PcType").
+		Preload("Pc.PcType.PcTypeTariffs", func(db *gorm2.DB) *gorm2.DB {
+			return db.Order("pc_type_tariff_id")
+		}).
+		First(&order, orderID); gorm.IsFailResult(res) {
+
+		return models.PcOrder{}, errors.WithMessage(errorByResult(res), op, "failed to get pc order")
+	}
+
+	return order, nil
+}
+
+// PcOrderHistory returns up to limit orders of the user with start time in [from, to)
+// ordered by start time and id descending. Only orders before the (before, beforeID)
+// key are returned when before is not zero, status and range bounds are not
+// filtered when they are empty
+func (s *Storage) PcOrderHistory(
+	ctx context.Context,
+	uid int64,
+	status string,
+	from time.Time,
+	to time.Time,
+	before time.Time,
+	beforeID int64,
+	limit int,
+) ([]models.PcOrder, error) {
+	const op = "storage.mssql.pc_order.PcOrderHistory"
+
+	query := s.db.WithContext(ctx).
+		Preload("PcOrderStatus").
+		Preload("Pc.PcType").
+		Preload("Pc.PcRoom").
+		Where("pc_orders.user_id = ?", uid)
+	if status != "" {
+		query = query.
+			Joins("JOIN dbo.pc_order_statuses pos ON pos.pc_order_status_id = pc_orders.pc_order_status_id").
+			Where("pos.name = ?", status)
+	}
+	if !from.IsZero() {
+		query = query.Where("pc_orders.start_time >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("pc_orders.start_time < ?", to)
+	}
+	if !before.IsZero() {
+		query = query.Where(
+			"pc_orders.start_time < ? OR (pc_orders.start_time = ? AND pc_orders.pc_order_id < ?)",
+			before, before, beforeID,
+		)
+	}
+
+	var orders []models.PcOrder
+	if res := query.
+		Order("pc_orders.start_time DESC, pc_orders.pc_order_id DESC").
+		Limit(limit).
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pc order history")
+	}
+
+	return orders, nil
+}
+
+// SavePcOrder books the order debiting its cost from the user balance
+// and used minutes from the user hour packages. Promotion is redeemed
+// for the order when redemption is not nil
+func (s *Storage) SavePcOrder(
+	ctx context.Context,
+	order *models.PcOrder,
+	usages []models.PcOrderHourPackage,
+	redemption *models.PromotionRedemption,
+) (int64, error) {
+	const op = "storage.mssql.pc_order.SavePcOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		statusID, err := pcOrderStatusID(tx, BookedPcOrderStatus)
+		if err != nil {
+			return err
+		}
+		order.PcOrderStatusID = statusID
+
+		if err := checkPcOrderOverlap(tx, order.PcID, order.StartTime, order.ActualEndTime, 0); err != nil {
+			return err
+		}
+
+		if res := tx.Create(order); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create pc order")
+		}
+
+		if err := debitBalance(tx, order.UserID, order.Cost, balanceEntry{
+			kind:      BookingChargeBalanceTransaction,
+			pcOrderID: order.PcOrderID,
+			actorID:   order.UserID,
+		}); err != nil {
+			return err
+		}
+
+		if err := useHourPackages(tx, order.UserID, order.PcOrderID, usages); err != nil {
+			return err
+		}
+
+		if redemption != nil {
+			redemption.UserID = order.UserID
+			redemption.PcOrderID = order.PcOrderID
+			if err := redeemPromotion(tx, redemption); err != nil {
+				return err
+			}
+		}
+
+		return recordPcOrderStatus(tx, []int64{order.PcOrderID}, "", BookedPcOrderStatus, order.UserID)
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to save pc order")
+	}
+
+	return order.PcOrderID, nil
+}
+
+// CheckInPcOrder activates booked order of the pc by its code and marks the pc occupied.
+// The order start time must be within [from, to]
+func (s *Storage) CheckInPcOrder(
+	ctx context.Context,
+	pcID int64,
+	code string,
+	from time.Time,
+	to time.Time,
+) (models.PcOrder, error) {
+	const op = "storage.mssql.pc_order.CheckInPcOrder"
+
+	var order models.PcOrder
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		bookedID, err := pcOrderStatusID(tx, BookedPcOrderStatus)
+		if err != nil {
+			return err
+		}
+
+		if res := tx.
+			Table(forUpdate(models.TableNamePcOrder)).
+			Where("pc_id = ? AND code = ? AND pc_order_status_id = ?", pcID, code, bookedID).
+			Order("start_time").
+			First(&order); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get booked pc order")
+		}
+
+		if order.StartTime.Before(from) || order.StartTime.After(to) {
+			return errors.WithMessage(ErrCheckFailed, "check in time is out of order window")
+		}
+
+		err = changePcOrderStatus(tx, order.PcOrderID, BookedPcOrderStatus, ActivePcOrderStatus, order.UserID)
+		if err != nil {
+			return err
+		}
+
+		order.PcOrderStatusID, err = pcOrderStatusID(tx, ActivePcOrderStatus)
+		if err != nil {
+			return err
+		}
+
+		return setPcStatus(tx, pcID, OccupiedPcStatus)
+	})
+	if err != nil {
+		return models.PcOrder{}, errors.WithMessage(err, op, "failed to check in pc order")
+	}
+
+	return order, nil
+}
+
+// CancelPcOrder cancels booked order by actor, credits refund to the user balance,
+// returns used hour package minutes to the user wallet and revokes promotion redemption
+func (s *Storage) CancelPcOrder(
+	ctx context.Context,
+	orderID int64,
+	uid int64,
+	actorID int64,
+	refund money.Money,
+) error {
+	const op = "storage.mssql.pc_order.CancelPcOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if err := changePcOrderStatus(tx, orderID, BookedPcOrderStatus, CancelledPcOrderStatus, actorID); err != nil {
+			return err
+		}
+
+		if err := returnHourPackages(tx, orderID); err != nil {
+			return err
+		}
+
+		if err := revokePromotionRedemption(tx, "pc_order_id", orderID); err != nil {
+			return err
+		}
+
+		return creditBalance(tx, uid, refund, balanceEntry{
+			kind:      RefundBalanceTransaction,
+			pcOrderID: orderID,
+			actorID:   actorID,
+			comment:   "pc order cancelled",
+		})
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to cancel pc order")
+	}
+
+	return nil
+}
+
+// ExtendPcOrder prolongs active order by minutes and debits cost from the user balance
+func (s *Storage) ExtendPcOrder(
+	ctx context.Context,
+	orderID int64,
+	uid int64,
+	minutes int16,
+	cost money.Money,
+) error {
+	const op = "storage.mssql.pc_order.ExtendPcOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		activeID, err := pcOrderStatusID(tx, ActivePcOrderStatus)
+		if err != nil {
+			return err
+		}
+
+		var order models.PcOrder
+		if res := tx.
+			Table(forUpdate(models.TableNamePcOrder)).
+			Where("pc_order_id = ? AND pc_order_status_id = ?", orderID, activeID).
+			First(&order); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get active pc order")
+		}
+
+		end := order.ActualEndTime.Add(time.Duration(minutes) * time.Minute)
+		if err := checkPcOrderOverlap(tx, order.PcID, order.ActualEndTime, end, order.PcOrderID); err != nil {
+			return err
+		}
+
+		if err := debitBalance(tx, uid, cost, balanceEntry{
+			kind:      BookingChargeBalanceTransaction,
+			pcOrderID: orderID,
+			actorID:   uid,
+			comment:   "pc order extended",
+		}); err != nil {
+			return err
+		}
+
+		if res := tx.Model(&order).Updates(map[string]interface{}{
+			"duration":        order.Duration + minutes,
+			"actual_end_time": end,
+			"cost":            order.Cost + cost,
+		}); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to update pc order")
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to extend pc order")
+	}
+
+	return nil
+}
+
+// EndPcOrder finishes active order at end time, frees the pc, credits refund
+// to the user balance and returns the unused minutes share of used hour packages
+func (s *Storage) EndPcOrder(
+	ctx context.Context,
+	orderID int64,
+	uid int64,
+	end time.Time,
+	refund money.Money,
+	unusedMinutes int16,
+) error {
+	const op = "storage.mssql.pc_order.EndPcOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if err := changePcOrderStatus(tx, orderID, ActivePcOrderStatus, FinishedPcOrderStatus, uid); err != nil {
+			return err
+		}
+
+		query := "UPDATE dbo.pc_orders SET actual_end_time = ?, cost = cost - ? WHERE pc_order_id = ?"
+		if res := tx.Exec(query, end, refund, orderID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to update pc order")
+		}
+
+		if err := creditBalance(tx, uid, refund, balanceEntry{
+			kind:      RefundBalanceTransaction,
+			pcOrderID: orderID,
+			actorID:   uid,
+			comment:   "pc order ended early",
+		}); err != nil {
+			return err
+		}
+
+		var order models.PcOrder
+		if res := tx.
+			Select("pc_id", "duration").
+			First(&order, orderID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get pc order")
+		}
+
+		if err := returnUnusedHourPackages(tx, orderID, unusedMinutes, order.Duration); err != nil {
+			return err
+		}
+
+		return setPcStatus(tx, order.PcID, AvailablePcStatus)
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to end pc order")
+	}
+
+	return nil
+}
+
+// changePcOrderStatus moves the order from status to status on behalf of actor,
+// it returns ErrCheckFailed when the transition is not allowed
+// or the order is not in from status
+func changePcOrderStatus(tx *gorm2.DB, orderID int64, from string, to string, actorID int64) error {
+	if err := CheckPcOrderTransition(from, to); err != nil {
+		return err
+	}
+
+	query := `
+UPDATE dbo.pc_orders
+SET pc_order_status_id = (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?)
+WHERE pc_order_id = ?
+  AND pc_order_status_id = (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?)`
+
+	res := tx.Exec(query, to, orderID, from)
+	if res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to change pc order status")
+	}
+	if res.RowsAffected == 0 {
+		return errors.WithMessage(ErrCheckFailed, "pc order is not "+from)
+	}
+
+	return recordPcOrderStatus(tx, []int64{orderID}, from, to, actorID)
+}
+
+// recordPcOrderStatus writes status changes of orders in history,
+// empty from means the order creation and zero actor means the system
+func recordPcOrderStatus(tx *gorm2.DB, orderIDs []int64, from string, to string, actorID int64) error {
+	query := `
+INSERT INTO dbo.pc_order_status_history (pc_order_id, from_pc_order_status_id, to_pc_order_status_id, user_id)
+SELECT
+    pc_orders.pc_order_id,
+    (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?),
+    (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?),
+    NULLIF(?, 0)
+FROM dbo.pc_orders
+WHERE pc_orders.pc_order_id IN ?`
+
+	if res := tx.Exec(query, from, to, actorID, orderIDs); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to record pc order status")
+	}
+
+	return nil
+}
+
+func pcOrderStatusID(tx *gorm2.DB, name string) (int64, error) {
+	var statusID int64
+	if res := tx.
+		Model(&models.PcOrderStatus{}).
+		Select("pc_order_status_id").
+		Where("name = ?", name).
+		First(&statusID); gorm.IsFailResult(res) {
+
+		return 0, errors.WithMessage(errorByResult(res), "failed to get pc order status "+name)
+	}
+
+	return statusID, nil
+}
+
+// checkPcOrderOverlap returns ErrCheckFailed when the pc has booked or active order
+// other than excludeID intersecting [start, end). Range locks are held until the end
+// of transaction, so concurrent bookings of the same pc wait for each other
+func checkPcOrderOverlap(tx *gorm2.DB, pcID int64, start time.Time, end time.Time, excludeID int64) error {
+	query := `
+SELECT COUNT(*)
+FROM dbo.pc_orders WITH (UPDLOCK, HOLDLOCK)
+JOIN dbo.pc_order_statuses ON pc_order_statuses.pc_order_status_id = pc_orders.pc_order_status_id
+WHERE pc_orders.pc_id = ?
+  AND pc_order_statuses.name IN ?
+  AND pc_orders.start_time < ?
+  AND pc_orders.actual_end_time > ?
+  AND pc_orders.pc_order_id <> ?`
+
+	var count int64
+	if res := tx.Raw(query, pcID, busyPcOrderStatuses, end, start, excludeID).Scan(&count); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to check pc order overlap")
+	}
+	if count > 0 {
+		return errors.WithMessage(ErrCheckFailed, "pc is already ordered for this time")
+	}
+
+	return nil
+}
+
+func setPcStatus(tx *gorm2.DB, pcID int64, status string) error {
+	query := `
+UPDATE dbo.pc
+SET pc_status_id = (SELECT pc_status_id FROM dbo.pc_statuses WHERE name = ?)
+WHERE pc_id = ?`
+
+	if res := tx.Exec(query, status, pcID); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to set pc status "+status)
+	}
+
+	return nil
+}
+
+// ExpirePcOrders moves booked orders started before the time to no show status,
+// their pcs are left as they are. It returns count of expired orders
+func (s *Storage) ExpirePcOrders(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	const op = "storage.mssql.pc_order.ExpirePcOrders"
+
+	count, err := s.closePcOrders(ctx, BookedPcOrderStatus, NoShowPcOrderStatus, "start_time", before)
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to expire pc orders")
+	}
+
+	return count, nil
+}
+
+// FinishPcOrders moves active orders ended before the time to finished status
+// and frees their pcs. It returns count of finished orders
+func (s *Storage) FinishPcOrders(
+	ctx context.Context,
+	before time.Time,
+) (int64, error) {
+	const op = "storage.mssql.pc_order.FinishPcOrders"
+
+	count, err := s.closePcOrders(ctx, ActivePcOrderStatus, FinishedPcOrderStatus, "actual_end_time", before)
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to finish pc orders")
+	}
+
+	return count, nil
+}
+
+// closePcOrders moves orders in from status with time column before the time
+// to status. Closing active orders makes their occupied pcs available unless
+// the pc has another active order, pcs in other statuses are kept
+func (s *Storage) closePcOrders(
+	ctx context.Context,
+	from string,
+	to string,
+	timeColumn string,
+	before time.Time,
+) (int64, error) {
+	if err := CheckPcOrderTransition(from, to); err != nil {
+		return 0, err
+	}
+
+	var closed []struct {
+		PcOrderID int64
+		PcID      int64
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		query := `
+UPDATE dbo.pc_orders
+SET pc_order_status_id = (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?)
+OUTPUT inserted.pc_order_id, inserted.pc_id
+WHERE pc_order_status_id = (SELECT pc_order_status_id FROM dbo.pc_order_statuses WHERE name = ?)
+  AND ` + timeColumn + ` <= ?`
+
+		if res := tx.Raw(query, to, from, before).Scan(&closed); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to update pc orders status")
+		}
+		if len(closed) == 0 {
+			return nil
+		}
+
+		orderIDs := make([]int64, 0, len(closed))
+		pcIDs := make([]int64, 0, len(closed))
+		for _, order := range closed {
+			orderIDs = append(orderIDs, order.PcOrderID)
+			pcIDs = append(pcIDs, order.PcID)
+		}
+
+		if err := recordPcOrderStatus(tx, orderIDs, from, to, 0); err != nil {
+			return err
+		}
+
+		// only active orders occupy pcs, booked ones never did
+		if from != ActivePcOrderStatus {
+			return nil
+		}
+
+		query = `
+UPDATE dbo.pc
+SET pc_status_id = (SELECT pc_status_id FROM dbo.pc_statuses WHERE name = ?)
+WHERE pc_id IN ?
+  AND pc_status_id = (SELECT pc_status_id FROM dbo.pc_statuses WHERE name = ?)
+  AND NOT EXISTS (
+      SELECT 1
+      FROM dbo.pc_orders
+      JOIN dbo.pc_order_statuses ON pc_order_statuses.pc_order_status_id = pc_orders.pc_order_status_id
+      WHERE pc_orders.pc_id = pc.pc_id AND pc_order_statuses.name = ?
+  )`
+
+		if res := tx.Exec(query, AvailablePcStatus, pcIDs, OccupiedPcStatus, ActivePcOrderStatus); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to free pcs")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(closed)), nil
+}
@@ -0,0 +1,172 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/lib/money"
+	"server/internal/models"
+	"time"
+)
+
+// PcOrderFilter selects pc orders by zero value ignoring fields,
+// start time is taken from [From, To)
+type PcOrderFilter struct {
+	RoomID int64
+	PcID   int64
+	UserID int64
+	Status string
+	From   time.Time
+	To     time.Time
+}
+
+type PcOrderStats struct {
+	Status string      `json:"status"`
+	Count  int64       `json:"count"`
+	Cost   money.Money `json:"cost"`
+}
+
+// FilterPcOrders returns page of orders matching the filter, the newest first
+func (s *Storage) FilterPcOrders(
+	ctx context.Context,
+	filter PcOrderFilter,
+	limit int,
+	offset int,
+) ([]models.PcOrder, error) {
+	const op = "storage.mssql.pc_order_admin.FilterPcOrders"
+
+	var orders []models.PcOrder
+	if res := filterPcOrders(s.db.WithContext(ctx), filter).
+		Preload("PcOrderStatus").
+		Preload("Pc.PcRoom").
+		Preload("User", func(db *gorm2.DB) *gorm2.DB {
+			return db.Select("user_id", "email")
+		}).
+		Order("pc_orders.start_time DESC, pc_orders.pc_order_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&orders); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to filter pc orders")
+	}
+
+	return orders, nil
+}
+
+// PcOrderStats returns count and cost sum of orders matching the filter by status
+func (s *Storage) PcOrderStats(
+	ctx context.Context,
+	filter PcOrderFilter,
+) ([]PcOrderStats, error) {
+	const op = "storage.mssql.pc_order_admin.PcOrderStats"
+
+	var stats []PcOrderStats
+	if res := filterPcOrders(s.db.WithContext(ctx).Model(&models.PcOrder{}), filter).
+		Select("pos.name AS status, COUNT(*) AS count, COALESCE(SUM(pc_orders.cost), 0) AS cost").
+		Group("pos.name").
+		Order("pos.name").
+		Scan(&stats); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pc order stats")
+	}
+
+	return stats, nil
+}
+
+// MovePcOrder moves booked order to the pc and start time keeping its duration,
+// the new time is checked against other orders of the pc. Difference between
+// the new cost and the order cost is debited from or credited to the user balance
+func (s *Storage) MovePcOrder(
+	ctx context.Context,
+	orderID int64,
+	adminID int64,
+	pcID int64,
+	startTime time.Time,
+	cost money.Money,
+) error {
+	const op = "storage.mssql.pc_order_admin.MovePcOrder"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		bookedID, err := pcOrderStatusID(tx, BookedPcOrderStatus)
+		if err != nil {
+			return err
+		}
+
+		var order models.PcOrder
+		if res := tx.
+			Table(forUpdate(models.TableNamePcOrder)).
+			Where("pc_order_id = ? AND pc_order_status_id = ?", orderID, bookedID).
+			First(&order); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get booked pc order")
+		}
+
+		end := startTime.Add(time.Duration(order.Duration) * time.Minute)
+		if err := checkPcOrderOverlap(tx, pcID, startTime, end, order.PcOrderID); err != nil {
+			return err
+		}
+
+		if cost > order.Cost {
+			if err := debitBalance(tx, order.UserID, cost-order.Cost, balanceEntry{
+				kind:      BookingChargeBalanceTransaction,
+				pcOrderID: orderID,
+				actorID:   adminID,
+				comment:   "pc order moved",
+			}); err != nil {
+				return err
+			}
+		} else if err := creditBalance(tx, order.UserID, order.Cost-cost, balanceEntry{
+			kind:      RefundBalanceTransaction,
+			pcOrderID: orderID,
+			actorID:   adminID,
+			comment:   "pc order moved",
+		}); err != nil {
+			return err
+		}
+
+		if res := tx.Model(&order).Updates(map[string]interface{}{
+			"pc_id":           pcID,
+			"start_time":      startTime,
+			"actual_end_time": end,
+			"cost":            cost,
+		}); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to update pc order")
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to move pc order")
+	}
+
+	return nil
+}
+
+func filterPcOrders(db *gorm2.DB, filter PcOrderFilter) *gorm2.DB {
+	db = db.
+		Joins("JOIN dbo.pc_order_statuses pos ON pos.pc_order_status_id = pc_orders.pc_order_status_id").
+		Joins("JOIN dbo.pc ON pc.pc_id = pc_orders.pc_id")
+
+	if filter.RoomID != 0 {
+		db = db.Where("pc.pc_room_id = ?", filter.RoomID)
+	}
+	if filter.PcID != 0 {
+		db = db.Where("pc_orders.pc_id = ?", filter.PcID)
+	}
+	if filter.UserID != 0 {
+		db = db.Where("pc_orders.user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		db = db.Where("pos.name = ?", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		db = db.Where("pc_orders.start_time >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		db = db.Where("pc_orders.start_time < ?", filter.To)
+	}
+
+	return db
+}
@@ -37,6 +37,7 @@ func (s *Storage) PcType(
 		Preload("VideoCard").Preload("VideoCard.VideoCardProducer").
 		Preload("Monitor").Preload("Monitor.MonitorProducer").
 		Preload("RAM").Preload("RAM.RAMType").
+		Preload("PcTypeImages", orderPcTypeImages).
 		First(&pcType, typeID); gorm.IsFailResult(res) {
 
 		return models.PcType{}, errors.WithMessage(errorByResult(res), op, "failed to get pc type")
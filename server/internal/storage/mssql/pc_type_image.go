@@ -0,0 +1,228 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+)
+
+// PcTypeImages returns gallery of the pc type, the main image first
+func (s *Storage) PcTypeImages(
+	ctx context.Context,
+	typeID int64,
+) ([]models.PcTypeImage, error) {
+	const op = "storage.mssql.pc_type_image.PcTypeImages"
+
+	var typeImages []models.PcTypeImage
+	if res := s.db.WithContext(ctx).
+		Where("pc_type_id = ?", typeID).
+		Scopes(orderPcTypeImages).
+		Find(&typeImages); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pc type images")
+	}
+
+	return typeImages, nil
+}
+
+// SavePcTypeImage appends the image to the end of pc type gallery, the first
+// image of the pc type becomes main and the main image replaces the previous one
+func (s *Storage) SavePcTypeImage(
+	ctx context.Context,
+	typeImage *models.PcTypeImage,
+) (int64, error) {
+	const op = "storage.mssql.pc_type_image.SavePcTypeImage"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var positions []int16
+		if res := tx.
+			Table(forUpdate(models.TableNamePcTypeImage)).
+			Where("pc_type_id = ?", typeImage.PcTypeID).
+			Pluck("position", &positions); res.Error != nil {
+
+			return errors.WithMessage(errorByResult(res), "failed to get pc type images")
+		}
+
+		typeImage.Position = 0
+		for _, position := range positions {
+			typeImage.Position = max(typeImage.Position, position+1)
+		}
+		if len(positions) == 0 {
+			typeImage.IsMain = true
+		}
+
+		if typeImage.IsMain {
+			if err := resetMainPcTypeImage(tx, typeImage.PcTypeID); err != nil {
+				return err
+			}
+		}
+
+		if res := tx.Create(typeImage); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create pc type image")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.WithMessage(err, op, "failed to save pc type image")
+	}
+
+	return typeImage.PcTypeImageID, nil
+}
+
+// ReorderPcTypeImages sets gallery order of the pc type, imageIDs
+// must contain every image of the pc type exactly once
+func (s *Storage) ReorderPcTypeImages(
+	ctx context.Context,
+	typeID int64,
+	imageIDs []int64,
+) error {
+	const op = "storage.mssql.pc_type_image.ReorderPcTypeImages"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		var ids []int64
+		if res := tx.
+			Table(forUpdate(models.TableNamePcTypeImage)).
+			Where("pc_type_id = ?", typeID).
+			Pluck("pc_type_image_id", &ids); res.Error != nil {
+
+			return errors.WithMessage(errorByResult(res), "failed to get pc type images")
+		}
+
+		current := make(map[int64]bool, len(ids))
+		for _, id := range ids {
+			current[id] = true
+		}
+		if len(imageIDs) != len(ids) {
+			return errors.WithMessage(ErrCheckFailed, "images do not match pc type gallery")
+		}
+		for _, id := range imageIDs {
+			if !current[id] {
+				return errors.WithMessage(ErrCheckFailed, "images do not match pc type gallery")
+			}
+			delete(current, id)
+		}
+
+		for position, id := range imageIDs {
+			if res := tx.
+				Model(&models.PcTypeImage{}).
+				Where("pc_type_image_id = ?", id).
+				Update("position", position); res.Error != nil {
+
+				return errors.WithMessage(errorByResult(res), "failed to update pc type image position")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to reorder pc type images")
+	}
+
+	return nil
+}
+
+// SetMainPcTypeImage makes the image main for its pc type and returns it
+func (s *Storage) SetMainPcTypeImage(
+	ctx context.Context,
+	imageID int64,
+) (models.PcTypeImage, error) {
+	const op = "storage.mssql.pc_type_image.SetMainPcTypeImage"
+
+	var typeImage models.PcTypeImage
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Table(forUpdate(models.TableNamePcTypeImage)).
+			First(&typeImage, imageID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get pc type image")
+		}
+
+		if err := resetMainPcTypeImage(tx, typeImage.PcTypeID); err != nil {
+			return err
+		}
+
+		if res := tx.
+			Model(&models.PcTypeImage{}).
+			Where("pc_type_image_id = ?", imageID).
+			Update("is_main", true); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to set main pc type image")
+		}
+		typeImage.IsMain = true
+
+		return nil
+	})
+	if err != nil {
+		return models.PcTypeImage{}, errors.WithMessage(err, op, "failed to set main pc type image")
+	}
+
+	return typeImage, nil
+}
+
+// DeletePcTypeImage deletes the image and returns it, when the main image
+// is deleted the first remaining image of the gallery becomes main
+func (s *Storage) DeletePcTypeImage(
+	ctx context.Context,
+	imageID int64,
+) (models.PcTypeImage, error) {
+	const op = "storage.mssql.pc_type_image.DeletePcTypeImage"
+
+	var typeImage models.PcTypeImage
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Table(forUpdate(models.TableNamePcTypeImage)).
+			First(&typeImage, imageID); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to get pc type image")
+		}
+
+		if res := tx.Delete(&models.PcTypeImage{}, imageID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to delete pc type image")
+		}
+
+		if !typeImage.IsMain {
+			return nil
+		}
+
+		query := `
+UPDATE dbo.pc_type_images
+SET is_main = 1
+WHERE pc_type_image_id = (
+    SELECT TOP 1 pc_type_image_id
+    FROM dbo.pc_type_images
+    WHERE pc_type_id = ?
+    ORDER BY position, pc_type_image_id
+)`
+
+		if res := tx.Exec(query, typeImage.PcTypeID); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to set main pc type image")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return models.PcTypeImage{}, errors.WithMessage(err, op, "failed to delete pc type image")
+	}
+
+	return typeImage, nil
+}
+
+// orderPcTypeImages orders gallery of a pc type with the main image first
+func orderPcTypeImages(db *gorm2.DB) *gorm2.DB {
+	return db.Order("is_main DESC, position, pc_type_image_id")
+}
+
+func resetMainPcTypeImage(tx *gorm2.DB, typeID int64) error {
+	if res := tx.
+		Model(&models.PcTypeImage{}).
+		Where("pc_type_id = ? AND is_main = ?", typeID, true).
+		Update("is_main", false); res.Error != nil {
+
+		return errors.WithMessage(errorByResult(res), "failed to reset main pc type image")
+	}
+
+	return nil
+}
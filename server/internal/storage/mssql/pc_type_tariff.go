@@ -0,0 +1,72 @@
+package mssql
+
+import (
+	"context"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+)
+
+// PcTypeTariffs returns tariffs of the pc type, pc type without
+// tariffs is charged by its hour cost, so empty list is not an error
+func (s *Storage) PcTypeTariffs(
+	ctx context.Context,
+	typeID int64,
+) ([]models.PcTypeTariff, error) {
+	const op = "storage.mssql.pc_type_tariff.PcTypeTariffs"
+
+	var tariffs []models.PcTypeTariff
+	if res := s.db.WithContext(ctx).
+		Where("pc_type_id = ?", typeID).
+		Order("pc_type_tariff_id").
+		Find(&tariffs); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get pc type tariffs")
+	}
+
+	return tariffs, nil
+}
+
+func (s *Storage) SavePcTypeTariff(
+	ctx context.Context,
+	tariff *models.PcTypeTariff,
+) (int64, error) {
+	const op = "storage.mssql.pc_type_tariff.SavePcTypeTariff"
+
+	if res := s.db.WithContext(ctx).Save(tariff); gorm.IsFailResult(res) {
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to save pc type tariff")
+	}
+
+	return tariff.PcTypeTariffID, nil
+}
+
+func (s *Storage) UpdatePcTypeTariff(
+	ctx context.Context,
+	tariffID int64,
+	tariff *models.PcTypeTariff,
+) error {
+	const op = "storage.mssql.pc_type_tariff.UpdatePcTypeTariff"
+
+	if res := s.db.WithContext(ctx).
+		Where("pc_type_tariff_id = ?", tariffID).
+		Select("name", "hour_cost", "start_minute", "end_minute", "weekdays").
+		Updates(tariff); gorm.IsFailResult(res) {
+
+		return errors.WithMessage(errorByResult(res), op, "failed to update pc type tariff")
+	}
+
+	return nil
+}
+
+func (s *Storage) DeletePcTypeTariff(
+	ctx context.Context,
+	tariffID int64,
+) error {
+	const op = "storage.mssql.pc_type_tariff.DeletePcTypeTariff"
+
+	if res := s.db.WithContext(ctx).Delete(&models.PcTypeTariff{}, tariffID); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), op, "failed to delete pc type tariff")
+	}
+
+	return nil
+}
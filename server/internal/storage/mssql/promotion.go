@@ -0,0 +1,231 @@
+package mssql
+
+import (
+	"context"
+	gorm2 "gorm.io/gorm"
+	"server/internal/lib/api/database/gorm"
+	"server/internal/lib/errors"
+	"server/internal/models"
+	"time"
+)
+
+func (s *Storage) Promotions(
+	ctx context.Context,
+) ([]models.Promotion, error) {
+	const op = "storage.mssql.promotion.Promotions"
+
+	var promotions []models.Promotion
+	if res := preloadPromotionScopes(s.db.WithContext(ctx)).
+		Order("promotion_id DESC").
+		Find(&promotions); res.Error != nil {
+
+		return nil, errors.WithMessage(errorByResult(res), op, "failed to get promotions")
+	}
+
+	return promotions, nil
+}
+
+func (s *Storage) PromotionByCode(
+	ctx context.Context,
+	code string,
+) (models.Promotion, error) {
+	const op = "storage.mssql.promotion.PromotionByCode"
+
+	var promotion models.Promotion
+	if res := preloadPromotionScopes(s.db.WithContext(ctx)).
+		Where("code = ?", code).
+		First(&promotion); gorm.IsFailResult(res) {
+
+		return models.Promotion{}, errors.WithMessage(errorByResult(res), op, "failed to get promotion")
+	}
+
+	return promotion, nil
+}
+
+func (s *Storage) SavePromotion(
+	ctx context.Context,
+	promotion *models.Promotion,
+) (int64, error) {
+	const op = "storage.mssql.promotion.SavePromotion"
+
+	if res := s.db.WithContext(ctx).Create(promotion); gorm.IsFailResult(res) {
+		return 0, errors.WithMessage(errorByResult(res), op, "failed to save promotion")
+	}
+
+	return promotion.PromotionID, nil
+}
+
+// UpdatePromotion updates promotion terms and replaces its scope,
+// usage count is kept
+func (s *Storage) UpdatePromotion(
+	ctx context.Context,
+	promotionID int64,
+	promotion *models.Promotion,
+) error {
+	const op = "storage.mssql.promotion.UpdatePromotion"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if res := tx.
+			Where("promotion_id = ?", promotionID).
+			Select("code", "name", "percent", "amount", "start_date", "end_date", "usage_limit", "user_usage_limit").
+			Updates(promotion); gorm.IsFailResult(res) {
+
+			return errors.WithMessage(errorByResult(res), "failed to update promotion")
+		}
+
+		if err := deletePromotionScopes(tx, promotionID); err != nil {
+			return err
+		}
+
+		return createPromotionScopes(tx, promotionID, promotion)
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to update promotion")
+	}
+
+	return nil
+}
+
+func (s *Storage) DeletePromotion(
+	ctx context.Context,
+	promotionID int64,
+) error {
+	const op = "storage.mssql.promotion.DeletePromotion"
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm2.DB) error {
+		if err := deletePromotionScopes(tx, promotionID); err != nil {
+			return err
+		}
+
+		if res := tx.Delete(&models.Promotion{}, promotionID); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to delete promotion")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithMessage(err, op, "failed to delete promotion")
+	}
+
+	return nil
+}
+
+func preloadPromotionScopes(db *gorm2.DB) *gorm2.DB {
+	return db.
+		Preload("PromotionPcTypes").
+		Preload("PromotionPcRooms").
+		Preload("PromotionDishes")
+}
+
+func createPromotionScopes(tx *gorm2.DB, promotionID int64, promotion *models.Promotion) error {
+	for i := range promotion.PromotionPcTypes {
+		promotion.PromotionPcTypes[i].PromotionID = promotionID
+	}
+	for i := range promotion.PromotionPcRooms {
+		promotion.PromotionPcRooms[i].PromotionID = promotionID
+	}
+	for i := range promotion.PromotionDishes {
+		promotion.PromotionDishes[i].PromotionID = promotionID
+	}
+
+	if len(promotion.PromotionPcTypes) != 0 {
+		if res := tx.Create(&promotion.PromotionPcTypes); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create promotion pc types")
+		}
+	}
+	if len(promotion.PromotionPcRooms) != 0 {
+		if res := tx.Create(&promotion.PromotionPcRooms); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create promotion pc rooms")
+		}
+	}
+	if len(promotion.PromotionDishes) != 0 {
+		if res := tx.Create(&promotion.PromotionDishes); gorm.IsFailResult(res) {
+			return errors.WithMessage(errorByResult(res), "failed to create promotion dishes")
+		}
+	}
+
+	return nil
+}
+
+func deletePromotionScopes(tx *gorm2.DB, promotionID int64) error {
+	for _, scope := range []any{&models.PromotionPcType{}, &models.PromotionPcRoom{}, &models.PromotionDish{}} {
+		if res := tx.Where("promotion_id = ?", promotionID).Delete(scope); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to delete promotion scope")
+		}
+	}
+
+	return nil
+}
+
+// redeemPromotion checks promotion validity window and usage limits
+// under lock, counts the usage and records redemption for the order
+func redeemPromotion(tx *gorm2.DB, redemption *models.PromotionRedemption) error {
+	var promotion models.Promotion
+	if res := tx.Table(forUpdate("dbo.promotions")).
+		First(&promotion, redemption.PromotionID); gorm.IsFailResult(res) {
+
+		return errors.WithMessage(errorByResult(res), "failed to get promotion")
+	}
+
+	now := time.Now()
+	if now.Before(promotion.StartDate) || !now.Before(promotion.EndDate) {
+		return errors.WithMessage(ErrCheckFailed, "promotion is not valid now")
+	}
+	if promotion.UsageLimit > 0 && promotion.UsageCount >= promotion.UsageLimit {
+		return errors.WithMessage(ErrCheckFailed, "promotion usage limit is reached")
+	}
+
+	if promotion.UserUsageLimit > 0 {
+		var used int64
+		query := "SELECT COUNT(*) FROM dbo.promotion_redemptions WITH (UPDLOCK, HOLDLOCK) " +
+			"WHERE promotion_id = ? AND user_id = ?"
+		if res := tx.Raw(query, promotion.PromotionID, redemption.UserID).Scan(&used); res.Error != nil {
+			return errors.WithMessage(errorByResult(res), "failed to count promotion redemptions")
+		}
+		if used >= int64(promotion.UserUsageLimit) {
+			return errors.WithMessage(ErrCheckFailed, "promotion user usage limit is reached")
+		}
+	}
+
+	query := "UPDATE dbo.promotions SET usage_count = usage_count + 1 WHERE promotion_id = ?"
+	if res := tx.Exec(query, promotion.PromotionID); gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to count promotion usage")
+	}
+
+	query = "INSERT INTO dbo.promotion_redemptions (promotion_id, user_id, pc_order_id, dish_order_id, amount) " +
+		"VALUES (?, ?, NULLIF(?, 0), NULLIF(?, 0), ?)"
+	res := tx.Exec(
+		query,
+		redemption.PromotionID,
+		redemption.UserID,
+		redemption.PcOrderID,
+		redemption.DishOrderID,
+		redemption.Amount,
+	)
+	if gorm.IsFailResult(res) {
+		return errors.WithMessage(errorByResult(res), "failed to record promotion redemption")
+	}
+
+	return nil
+}
+
+// revokePromotionRedemption deletes redemption of the cancelled order and
+// gives its use back to the promotion, orderColumn is pc_order_id or dish_order_id
+func revokePromotionRedemption(tx *gorm2.DB, orderColumn string, orderID int64) error {
+	query := `
+UPDATE dbo.promotions
+SET usage_count = usage_count - 1
+WHERE promotion_id IN (SELECT promotion_id FROM dbo.promotion_redemptions WHERE ` + orderColumn + ` = ?)
+  AND usage_count > 0`
+
+	if res := tx.Exec(query, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to revoke promotion usage")
+	}
+
+	query = "DELETE FROM dbo.promotion_redemptions WHERE " + orderColumn + " = ?"
+	if res := tx.Exec(query, orderID); res.Error != nil {
+		return errors.WithMessage(errorByResult(res), "failed to delete promotion redemption")
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"gorm.io/gorm/logger"
 	"server/internal/config"
 	"server/internal/lib/api/database/mssql"
+	"server/internal/lib/errors"
+	"server/internal/models"
 )
 
 type Storage struct {
@@ -16,8 +18,98 @@ type Storage struct {
 
 const (
 	AvailablePcStatus = "available"
+	OccupiedPcStatus  = "occupied"
+
+	BookedPcOrderStatus    = "booked"
+	ActivePcOrderStatus    = "active"
+	CancelledPcOrderStatus = "cancelled"
+	FinishedPcOrderStatus  = "finished"
+	NoShowPcOrderStatus    = "no_show"
+
+	AvailableDishStatus  = "available"
+	OutOfStockDishStatus = "out_of_stock"
+
+	TopUpBalanceTransaction         = "top_up"
+	BookingChargeBalanceTransaction = "booking_charge"
+	DishChargeBalanceTransaction    = "dish_charge"
+	PackageChargeBalanceTransaction = "package_charge"
+	RefundBalanceTransaction        = "refund"
+	AdjustmentBalanceTransaction    = "adjustment"
+	PaymentRefundBalanceTransaction = "payment_refund"
+
+	PendingPaymentStatus   = "pending"
+	SucceededPaymentStatus = "succeeded"
+	FailedPaymentStatus    = "failed"
+	RefundedPaymentStatus  = "refunded"
+
+	AcceptedDishOrderStatus  = "accepted"
+	CookingDishOrderStatus   = "cooking"
+	ReadyDishOrderStatus     = "ready"
+	DeliveredDishOrderStatus = "delivered"
+	CancelledDishOrderStatus = "cancelled"
 )
 
+// busyPcOrderStatuses are statuses of orders which hold the pc
+var busyPcOrderStatuses = []string{BookedPcOrderStatus, ActivePcOrderStatus}
+
+// openDishOrderStatuses are statuses of orders which the kitchen still works on
+var openDishOrderStatuses = []string{AcceptedDishOrderStatus, CookingDishOrderStatus, ReadyDishOrderStatus}
+
+// pcOrderTransitions declares statuses pc order can be moved to from each status,
+// storage refuses any other status change
+var pcOrderTransitions = map[string][]string{
+	BookedPcOrderStatus: {
+		ActivePcOrderStatus,
+		CancelledPcOrderStatus,
+		NoShowPcOrderStatus,
+	},
+	ActivePcOrderStatus: {
+		FinishedPcOrderStatus,
+	},
+}
+
+// dishOrderTransitions declares statuses dish order can be moved to from each status,
+// storage refuses any other status change
+var dishOrderTransitions = map[string][]string{
+	AcceptedDishOrderStatus: {
+		CookingDishOrderStatus,
+		CancelledDishOrderStatus,
+	},
+	CookingDishOrderStatus: {
+		ReadyDishOrderStatus,
+	},
+	ReadyDishOrderStatus: {
+		DeliveredDishOrderStatus,
+	},
+}
+
+// CheckPcOrderTransition returns ErrCheckFailed when pc order status
+// cannot be changed from to, services use it to reject changes early
+func CheckPcOrderTransition(from string, to string) error {
+	return checkTransition(pcOrderTransitions, from, to)
+}
+
+// CheckDishOrderTransition returns ErrCheckFailed when dish order status
+// cannot be changed from to, services use it to reject changes early
+func CheckDishOrderTransition(from string, to string) error {
+	return checkTransition(dishOrderTransitions, from, to)
+}
+
+// checkTransition returns ErrCheckFailed when transitions do not allow
+// to change status from to
+func checkTransition(transitions map[string][]string, from string, to string) error {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+
+	return errors.WithMessage(ErrCheckFailed, "illegal status transition "+from+" -> "+to)
+}
+
+// OrderableDishStatuses are statuses of dishes which can be ordered
+var OrderableDishStatuses = []string{AvailableDishStatus}
+
 func New(cfg *config.SQLServerConfig) (*Storage, error) {
 	const op = "storage.mssql.New"
 
@@ -29,8 +121,40 @@ func New(cfg *config.SQLServerConfig) (*Storage, error) {
 		return nil, fmt.Errorf("%s: failed to connect: %w", op, err)
 	}
 
+	if err := ensureIndexes(db); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return &Storage{
 		db:  db,
 		cfg: cfg,
 	}, nil
 }
+
+// ensureIndexes creates unique indexes which storage relies on
+// when they are missing in the database
+func ensureIndexes(db *gorm.DB) error {
+	indexes := []struct {
+		model interface{}
+		name  string
+	}{
+		{&models.Payment{}, "ux_payments_provider_event_id"},
+	}
+
+	for _, index := range indexes {
+		if db.Migrator().HasIndex(index.model, index.name) {
+			continue
+		}
+		if err := db.Migrator().CreateIndex(index.model, index.name); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index.name, err)
+		}
+	}
+
+	return nil
+}
+
+// forUpdate returns table expression which locks selected rows
+// until the end of transaction
+func forUpdate(table string) string {
+	return table + " WITH (UPDLOCK, ROWLOCK)"
+}
@@ -44,6 +44,40 @@ func (s *Storage) Set(
 	return nil
 }
 
+func (s *Storage) Delete(
+	ctx context.Context,
+	key string,
+) error {
+	const op = "storage.redis.Delete"
+
+	err := s.cl.Del(ctx, key).Err()
+	if err != nil {
+		return fmt.Errorf("%s: failed to delete key: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteByPattern deletes all keys matching glob style pattern
+func (s *Storage) DeleteByPattern(
+	ctx context.Context,
+	pattern string,
+) error {
+	const op = "storage.redis.DeleteByPattern"
+
+	iter := s.cl.Scan(ctx, 0, pattern, 0).Iterator()
+	for iter.Next(ctx) {
+		if err := s.cl.Del(ctx, iter.Val()).Err(); err != nil {
+			return fmt.Errorf("%s: failed to delete key: %w", op, err)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("%s: failed to scan keys: %w", op, err)
+	}
+
+	return nil
+}
+
 func (s *Storage) StringValue(
 	ctx context.Context,
 	key string,